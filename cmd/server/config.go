@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func newConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the server configuration",
+	}
+	cmd.AddCommand(newConfigPrintCommand())
+	return cmd
+}
+
+func newConfigPrintCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			redacted := cfg.Redacted()
+			switch output {
+			case "yaml":
+				encoder := yaml.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent(2)
+				return encoder.Encode(redacted)
+			case "toml":
+				return toml.NewEncoder(cmd.OutOrStdout()).Encode(redacted)
+			default:
+				return fmt.Errorf("--output must be yaml or toml, got %q", output)
+			}
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "output format: yaml or toml")
+	return cmd
+}
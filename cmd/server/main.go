@@ -2,49 +2,310 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"entgo.io/ent/dialect"
-	_ "github.com/mattn/go-sqlite3"
+	apikeyv1 "github.com/sdoshi579/cloudbees/gen/apikey/v1"
+	"github.com/sdoshi579/cloudbees/gen/apikey/v1/apikeyv1connect"
 	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
-	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"github.com/sdoshi579/cloudbees/gen/post/v1/postv1connect"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/certs"
+	"github.com/sdoshi579/cloudbees/internal/config"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	"github.com/sdoshi579/cloudbees/internal/database/migration"
+	"github.com/sdoshi579/cloudbees/internal/health"
+	"github.com/sdoshi579/cloudbees/internal/logging"
+	"github.com/sdoshi579/cloudbees/internal/metrics"
+	apikeyrepo "github.com/sdoshi579/cloudbees/internal/repository/apikey"
+	_ "github.com/sdoshi579/cloudbees/internal/repository/ent/runtime"
 	postrepo "github.com/sdoshi579/cloudbees/internal/repository/post"
+	"github.com/sdoshi579/cloudbees/internal/retention"
+	"github.com/sdoshi579/cloudbees/internal/server"
+	apikeyservice "github.com/sdoshi579/cloudbees/internal/service/apikey"
 	postservice "github.com/sdoshi579/cloudbees/internal/service/post"
+	"github.com/sdoshi579/cloudbees/internal/tracing"
+	apikeyrpc "github.com/sdoshi579/cloudbees/rpc/apikey"
+	"github.com/sdoshi579/cloudbees/rpc/connectbridge"
+	"github.com/sdoshi579/cloudbees/rpc/descriptor"
+	"github.com/sdoshi579/cloudbees/rpc/gateway"
+	"github.com/sdoshi579/cloudbees/rpc/openapi"
 	postrpc "github.com/sdoshi579/cloudbees/rpc/post"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
-	"log"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// cfg is the effective configuration, loaded before any command runs.
+var cfg *config.Config
+
 func main() {
-	logger := zap.NewExample()
-	entClient, err := ent.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "server",
+		Short:        "Serve the post gRPC API",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			cfg, err = config.Load(cmd.Flags(), os.LookupEnv)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return serve(ctx)
+		},
+	}
+	config.RegisterFlags(cmd.PersistentFlags())
+	cmd.AddCommand(newMigrateCommand(), newConfigCommand())
+	return cmd
+}
+
+func serve(ctx context.Context) error {
+	logger, err := cfg.Log.NewLogger()
 	if err != nil {
-		log.Fatalf("failed opening connection to sqlite: %v", err)
+		return err
 	}
-	if err := entClient.Schema.Create(context.Background()); err != nil {
-		logger.Error("error in creating migration", zap.Error(err))
-		os.Exit(1)
+	defer logger.Sync()
+
+	db, err := database.OpenDB(ctx, database.Config{Driver: cfg.Database.Driver, DSN: cfg.Database.DSN})
+	if err != nil {
+		return err
 	}
+	migrator, err := migration.New(db, cfg.Database.Driver)
+	if err != nil {
+		return err
+	}
+	if err := migrator.Check(ctx); err != nil {
+		logger.Error("refusing to start, run `server migrate up` first", zap.Error(err))
+		return err
+	}
+	tracerProvider, shutdownTracing, err := newTracerProvider(ctx, cfg.Tracing)
+	if err != nil {
+		logger.Error("error in configuring tracing", zap.Error(err))
+		return err
+	}
+	defer shutdownTracing()
+	entClient := database.NewClient(cfg.Database.Driver, db, func(drv dialect.Driver) dialect.Driver {
+		return tracing.NewDriver(drv, tracerProvider)
+	})
 	defer entClient.Close()
 
 	logger.Info("initialized ent client")
-	repository := postrepo.NewRepository(postrepo.WithEntClient(entClient), postrepo.WithLogger(logger))
+	registry := metrics.NewRegistry()
+	repository := postrepo.NewRepository(postrepo.WithEntClient(entClient), postrepo.WithLogger(logger),
+		postrepo.WithMetrics(registry), postrepo.WithTracerProvider(tracerProvider))
+	registry.MustRegister(metrics.NewPostCollector(repository, logger))
+	apiKeyRepository := apikeyrepo.NewRepository(apikeyrepo.WithEntClient(entClient), apikeyrepo.WithLogger(logger))
 	logger.Info("initialized repository")
-	service := postservice.NewService(postservice.WithLogger(logger), postservice.WithRepository(repository))
+	serviceConfigs := []postservice.ServiceConfiguration{
+		postservice.WithRepository(repository),
+		postservice.WithTracerProvider(tracerProvider),
+	}
+	apiKeyServiceConfigs := []apikeyservice.ServiceConfiguration{
+		apikeyservice.WithLogger(logger), apikeyservice.WithRepository(apiKeyRepository),
+	}
+	if cfg.Auth.Enabled {
+		serviceConfigs = append(serviceConfigs, postservice.WithAuthorization())
+		apiKeyServiceConfigs = append(apiKeyServiceConfigs, apikeyservice.WithAuthorization())
+	}
+	service := postservice.NewService(serviceConfigs...)
+	apiKeyService := apikeyservice.NewService(apiKeyServiceConfigs...)
 	logger.Info("initialized service")
-	lis, err := net.Listen("tcp", ":8080")
+
+	// interceptors run for gRPC requests on server.address and for the
+	// Connect, gRPC and gRPC-Web requests on server.http_address alike,
+	// tracing, logging and metrics come first so rejected calls are recorded
+	// too, logging after tracing so its logger carries the trace ID
+	tracingInterceptor := tracing.NewInterceptor(tracerProvider)
+	loggingInterceptor := logging.NewInterceptor(logger)
+	metricsInterceptor := metrics.NewInterceptor(registry)
+	interceptors := []grpc.UnaryServerInterceptor{tracingInterceptor.Unary(), loggingInterceptor.Unary(),
+		metricsInterceptor.Unary()}
+	streamInterceptors := []grpc.StreamServerInterceptor{tracingInterceptor.Stream(), loggingInterceptor.Stream(),
+		metricsInterceptor.Stream()}
+	if cfg.Auth.Enabled {
+		verifier, err := newJWTVerifier(cfg.Auth)
+		if err != nil {
+			logger.Error("error in configuring authentication, set auth.hs256_secret or auth.jwks_file or disable auth.enabled",
+				zap.Error(err))
+			return err
+		}
+		authConfigs := []auth.InterceptorConfiguration{auth.WithAPIKeys(apiKeyService)}
+		if cfg.Server.TLS.ClientCAFile != "" {
+			authConfigs = append(authConfigs, auth.WithClientCertificates())
+		}
+		authInterceptor := auth.NewInterceptor(verifier, logger, authConfigs...)
+		interceptors = append(interceptors, authInterceptor.Unary())
+		streamInterceptors = append(streamInterceptors, authInterceptor.Stream())
+	} else {
+		logger.Warn("authentication is disabled, anyone can call the API")
+	}
+	// background work is stopped and awaited before the client is closed
+	var background sync.WaitGroup
+	defer background.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if cfg.Retention.PurgeAfter > 0 {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			retention.NewJob(service, cfg.Retention.PurgeAfter, cfg.Retention.PurgeInterval, logger).Run(ctx)
+		}()
+		logger.Info("started retention job", zap.Duration("purgeAfter", cfg.Retention.PurgeAfter))
+	}
+	lis, err := net.Listen("tcp", cfg.Server.Address)
 	if err != nil {
-		log.Fatalf("failed to listen on port 50051: %v", err)
+		logger.Error("error in listening", zap.Error(err), zap.String("address", cfg.Server.Address))
+		return err
 	}
+	httpLis, err := net.Listen("tcp", cfg.Server.HTTPAddress)
+	if err != nil {
+		logger.Error("error in listening", zap.Error(err), zap.String("address", cfg.Server.HTTPAddress))
+		return err
+	}
+
+	checker := health.NewChecker([]string{postv1.PostService_ServiceDesc.ServiceName},
+		health.WithCheck("migrations", migrator.Check),
+		health.WithCheck("database", db.PingContext),
+		health.WithInterval(cfg.Server.HealthCheckInterval),
+		health.WithLogger(logger),
+	)
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		checker.Run(ctx)
+	}()
 
-	s := grpc.NewServer()
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors...), grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+	var dialOptions []grpc.DialOption
+	if cfg.Server.TLS.Enabled() {
+		reloader, err := certs.NewReloader(cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile,
+			certs.WithClientCAFile(cfg.Server.TLS.ClientCAFile),
+			certs.WithInterval(cfg.Server.TLS.ReloadInterval),
+			certs.WithLogger(logger),
+		)
+		if err != nil {
+			logger.Error("error in loading TLS certificate", zap.Error(err))
+			return err
+		}
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			reloader.Run(ctx)
+		}()
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(reloader.ServerConfig("h2"))))
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(reloader.LoopbackClientConfig())))
+		httpLis = tls.NewListener(httpLis, reloader.ServerConfig("h2", "http/1.1"))
+		logger.Info("enabled TLS", zap.Bool("clientCertificates", cfg.Server.TLS.ClientCAFile != ""))
+	}
+	s := grpc.NewServer(serverOptions...)
 
 	postRPCInstance := postrpc.NewRPCImplementation(service, logger)
 	postv1.RegisterPostServiceServer(s, postRPCInstance)
+	apiKeyRPCInstance := apikeyrpc.NewRPCImplementation(apiKeyService, logger)
+	apikeyv1.RegisterApiKeyServiceServer(s, apiKeyRPCInstance)
+	healthpb.RegisterHealthServer(s, checker.Server())
 
-	log.Printf("gRPC server listening at %v", lis.Addr())
-	if err := s.Serve(lis); err != nil {
-		panic(err)
+	// the REST gateway calls the gRPC server over loopback
+	conn, err := gateway.Dial(lis.Addr(), dialOptions...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	gatewayHandler, err := gateway.NewHandler(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(postv1connect.NewPostServiceHandler(postrpc.NewConnectHandler(postRPCInstance, interceptors...)))
+	mux.Handle(apikeyv1connect.NewApiKeyServiceHandler(apikeyrpc.NewConnectHandler(apiKeyRPCInstance, interceptors...)))
+	mux.Handle(gateway.Prefix, gatewayHandler)
+	mux.Handle(openapi.SpecPath, openapi.SpecHandler())
+	mux.Handle(openapi.UIPath, openapi.UIHandler())
+	mux.Handle("/healthz", checker.Handler())
+	mux.Handle("/readyz", checker.Handler())
+	mux.Handle("/metrics", metrics.Handler(registry))
+	if cfg.Server.Reflection {
+		reflection.Register(s)
+		mux.Handle("/descriptors", descriptor.Handler(postv1.File_post_v1_post_proto, apikeyv1.File_apikey_v1_apikey_proto,
+			healthpb.File_grpc_health_v1_health_proto))
+		logger.Info("enabled server reflection")
+	}
+
+	logger.Info("gRPC server listening", zap.Stringer("address", lis.Addr()), zap.Stringer("httpAddress", httpLis.Addr()))
+	srv := server.NewServer(s,
+		// h2c serves HTTP/2 without TLS, which gRPC clients need when TLS is off
+		server.WithHTTPServer(&http.Server{Handler: h2c.NewHandler(connectbridge.Handler(mux), &http2.Server{}),
+			ReadHeaderTimeout: 10 * time.Second}, httpLis),
+		server.WithOnShutdown(checker.Shutdown),
+		server.WithDrainTimeout(cfg.Server.ShutdownTimeout),
+		server.WithLogger(logger),
+	)
+	if err := srv.Serve(ctx, lis); err != nil {
+		logger.Error("error in serving", zap.Error(err))
+		return err
+	}
+	logger.Info("server stopped")
+	return nil
+}
+
+// newTracerProvider returns the provider of the configured exporter, or one
+// recording nothing, and a function flushing the spans not exported yet.
+func newTracerProvider(ctx context.Context, tracingConfig config.TracingConfig) (trace.TracerProvider, func(), error) {
+	if tracingConfig.Exporter == tracing.ExporterNone {
+		return noop.NewTracerProvider(), func() {}, nil
+	}
+	provider, err := tracing.NewTracerProvider(ctx, tracing.Config{
+		Exporter:    tracingConfig.Exporter,
+		Endpoint:    tracingConfig.Endpoint,
+		Insecure:    tracingConfig.Insecure,
+		SampleRatio: tracingConfig.SampleRatio,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return provider, func() {
+		// ctx is done by now, the last spans get a moment of their own
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		provider.Shutdown(ctx)
+	}, nil
+}
+
+func newJWTVerifier(authConfig config.AuthConfig) (*auth.JWTVerifier, error) {
+	options := []auth.JWTVerifierConfiguration{auth.WithIssuer(authConfig.Issuer), auth.WithAudience(authConfig.Audience)}
+	if authConfig.HS256Secret != "" {
+		options = append(options, auth.WithHS256Secret([]byte(authConfig.HS256Secret)))
+	}
+	if authConfig.JWKSFile != "" {
+		keys, err := auth.LoadJWKS(authConfig.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, auth.WithRS256Keys(keys))
 	}
+	return auth.NewJWTVerifier(options...)
 }
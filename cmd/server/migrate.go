@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	"github.com/sdoshi579/cloudbees/internal/database/migration"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the database schema",
+	}
+	cmd.AddCommand(newMigrateUpCommand(), newMigrateDownCommand(), newMigrateStatusCommand(), newMigrateDiffCommand())
+	return cmd
+}
+
+func newMigrateUpCommand() *cobra.Command {
+	var steps int
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := openMigrator(cmd)
+			if err != nil {
+				return err
+			}
+			applied, err := migrator.Up(cmd.Context(), steps)
+			for _, m := range applied {
+				cmd.Printf("applied %d_%s\n", m.Version, m.Name)
+			}
+			if err == nil && len(applied) == 0 {
+				cmd.Println("no pending migrations")
+			}
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&steps, "steps", 0, "number of migrations to apply, 0 applies all")
+	return cmd
+}
+
+func newMigrateDownCommand() *cobra.Command {
+	var steps int
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := openMigrator(cmd)
+			if err != nil {
+				return err
+			}
+			reverted, err := migrator.Down(cmd.Context(), steps)
+			for _, m := range reverted {
+				cmd.Printf("reverted %d_%s\n", m.Version, m.Name)
+			}
+			if err == nil && len(reverted) == 0 {
+				cmd.Println("no applied migrations")
+			}
+			return err
+		},
+	}
+	cmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+	return cmd
+}
+
+func newMigrateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrator, err := openMigrator(cmd)
+			if err != nil {
+				return err
+			}
+			status, err := migrator.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+			for _, m := range status.Applied {
+				cmd.Printf("applied  %d_%s\n", m.Version, m.Name)
+			}
+			for _, m := range status.Pending {
+				cmd.Printf("pending  %d_%s\n", m.Version, m.Name)
+			}
+			cmd.Printf("version %d of %d", status.Current, status.Latest)
+			if status.Dirty {
+				cmd.Print(" (dirty)")
+			}
+			cmd.Println()
+			return nil
+		},
+	}
+}
+
+func newMigrateDiffCommand() *cobra.Command {
+	var dir, devURL string
+	cmd := &cobra.Command{
+		Use:   "diff NAME",
+		Short: "Generate a migration from changes to the ent schema",
+		Long: "Generate a migration from changes to the ent schema. The existing migrations are replayed on\n" +
+			"the empty dev database to compute the current state, so it must not be the real database.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dir == "" {
+				dir = migration.SourceDir(cfg.Database.Driver)
+			}
+			if devURL == "" {
+				devURL = migration.DefaultDevURL(cfg.Database.Driver)
+			}
+			if devURL == "" {
+				return fmt.Errorf("--dev-url is required for %s", cfg.Database.Driver)
+			}
+			err := migration.Diff(cmd.Context(), cfg.Database.Driver, dir, devURL, args[0])
+			if errors.Is(err, migration.ErrNoChanges) {
+				cmd.Println(err)
+				return nil
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "migration directory, defaults to the bundled one of --db-driver")
+	cmd.Flags().StringVar(&devURL, "dev-url", "", "atlas URL of an empty scratch database, required unless --db-driver is sqlite3")
+	return cmd
+}
+
+func openMigrator(cmd *cobra.Command) (*migration.Migrator, error) {
+	db, err := database.OpenDB(cmd.Context(), database.Config{Driver: cfg.Database.Driver, DSN: cfg.Database.DSN})
+	if err != nil {
+		return nil, err
+	}
+	return migration.New(db, cfg.Database.Driver)
+}
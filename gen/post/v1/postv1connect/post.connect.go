@@ -0,0 +1,411 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: post/v1/post.proto
+
+package postv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// PostServiceName is the fully-qualified name of the PostService service.
+	PostServiceName = "post.v1.PostService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PostServiceCreateProcedure is the fully-qualified name of the PostService's Create RPC.
+	PostServiceCreateProcedure = "/post.v1.PostService/Create"
+	// PostServiceGetProcedure is the fully-qualified name of the PostService's Get RPC.
+	PostServiceGetProcedure = "/post.v1.PostService/Get"
+	// PostServiceUpdateProcedure is the fully-qualified name of the PostService's Update RPC.
+	PostServiceUpdateProcedure = "/post.v1.PostService/Update"
+	// PostServiceDeleteProcedure is the fully-qualified name of the PostService's Delete RPC.
+	PostServiceDeleteProcedure = "/post.v1.PostService/Delete"
+	// PostServiceListProcedure is the fully-qualified name of the PostService's List RPC.
+	PostServiceListProcedure = "/post.v1.PostService/List"
+	// PostServiceRestoreProcedure is the fully-qualified name of the PostService's Restore RPC.
+	PostServiceRestoreProcedure = "/post.v1.PostService/Restore"
+	// PostServicePurgeProcedure is the fully-qualified name of the PostService's Purge RPC.
+	PostServicePurgeProcedure = "/post.v1.PostService/Purge"
+	// PostServiceListRevisionsProcedure is the fully-qualified name of the PostService's ListRevisions
+	// RPC.
+	PostServiceListRevisionsProcedure = "/post.v1.PostService/ListRevisions"
+	// PostServiceGetRevisionProcedure is the fully-qualified name of the PostService's GetRevision RPC.
+	PostServiceGetRevisionProcedure = "/post.v1.PostService/GetRevision"
+	// PostServiceDiffRevisionsProcedure is the fully-qualified name of the PostService's DiffRevisions
+	// RPC.
+	PostServiceDiffRevisionsProcedure = "/post.v1.PostService/DiffRevisions"
+	// PostServiceRestoreRevisionProcedure is the fully-qualified name of the PostService's
+	// RestoreRevision RPC.
+	PostServiceRestoreRevisionProcedure = "/post.v1.PostService/RestoreRevision"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	postServiceServiceDescriptor               = v1.File_post_v1_post_proto.Services().ByName("PostService")
+	postServiceCreateMethodDescriptor          = postServiceServiceDescriptor.Methods().ByName("Create")
+	postServiceGetMethodDescriptor             = postServiceServiceDescriptor.Methods().ByName("Get")
+	postServiceUpdateMethodDescriptor          = postServiceServiceDescriptor.Methods().ByName("Update")
+	postServiceDeleteMethodDescriptor          = postServiceServiceDescriptor.Methods().ByName("Delete")
+	postServiceListMethodDescriptor            = postServiceServiceDescriptor.Methods().ByName("List")
+	postServiceRestoreMethodDescriptor         = postServiceServiceDescriptor.Methods().ByName("Restore")
+	postServicePurgeMethodDescriptor           = postServiceServiceDescriptor.Methods().ByName("Purge")
+	postServiceListRevisionsMethodDescriptor   = postServiceServiceDescriptor.Methods().ByName("ListRevisions")
+	postServiceGetRevisionMethodDescriptor     = postServiceServiceDescriptor.Methods().ByName("GetRevision")
+	postServiceDiffRevisionsMethodDescriptor   = postServiceServiceDescriptor.Methods().ByName("DiffRevisions")
+	postServiceRestoreRevisionMethodDescriptor = postServiceServiceDescriptor.Methods().ByName("RestoreRevision")
+)
+
+// PostServiceClient is a client for the post.v1.PostService service.
+type PostServiceClient interface {
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	Update(context.Context, *connect.Request[v1.UpdateRequest]) (*connect.Response[v1.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error)
+	// Undeletes a soft-deleted post.
+	Restore(context.Context, *connect.Request[v1.RestoreRequest]) (*connect.Response[v1.RestoreResponse], error)
+	// Permanently removes a soft-deleted post and its revisions.
+	Purge(context.Context, *connect.Request[v1.PurgeRequest]) (*connect.Response[v1.PurgeResponse], error)
+	ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error)
+	GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.GetRevisionResponse], error)
+	DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error)
+	// Writes an earlier revision back to the post as a new revision.
+	RestoreRevision(context.Context, *connect.Request[v1.RestoreRevisionRequest]) (*connect.Response[v1.RestoreRevisionResponse], error)
+}
+
+// NewPostServiceClient constructs a client for the post.v1.PostService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPostServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PostServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &postServiceClient{
+		create: connect.NewClient[v1.CreateRequest, v1.CreateResponse](
+			httpClient,
+			baseURL+PostServiceCreateProcedure,
+			connect.WithSchema(postServiceCreateMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[v1.GetRequest, v1.GetResponse](
+			httpClient,
+			baseURL+PostServiceGetProcedure,
+			connect.WithSchema(postServiceGetMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[v1.UpdateRequest, v1.UpdateResponse](
+			httpClient,
+			baseURL+PostServiceUpdateProcedure,
+			connect.WithSchema(postServiceUpdateMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.DeleteRequest, v1.DeleteResponse](
+			httpClient,
+			baseURL+PostServiceDeleteProcedure,
+			connect.WithSchema(postServiceDeleteMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[v1.ListRequest, v1.ListResponse](
+			httpClient,
+			baseURL+PostServiceListProcedure,
+			connect.WithSchema(postServiceListMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		restore: connect.NewClient[v1.RestoreRequest, v1.RestoreResponse](
+			httpClient,
+			baseURL+PostServiceRestoreProcedure,
+			connect.WithSchema(postServiceRestoreMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		purge: connect.NewClient[v1.PurgeRequest, v1.PurgeResponse](
+			httpClient,
+			baseURL+PostServicePurgeProcedure,
+			connect.WithSchema(postServicePurgeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		listRevisions: connect.NewClient[v1.ListRevisionsRequest, v1.ListRevisionsResponse](
+			httpClient,
+			baseURL+PostServiceListRevisionsProcedure,
+			connect.WithSchema(postServiceListRevisionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getRevision: connect.NewClient[v1.GetRevisionRequest, v1.GetRevisionResponse](
+			httpClient,
+			baseURL+PostServiceGetRevisionProcedure,
+			connect.WithSchema(postServiceGetRevisionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		diffRevisions: connect.NewClient[v1.DiffRevisionsRequest, v1.DiffRevisionsResponse](
+			httpClient,
+			baseURL+PostServiceDiffRevisionsProcedure,
+			connect.WithSchema(postServiceDiffRevisionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		restoreRevision: connect.NewClient[v1.RestoreRevisionRequest, v1.RestoreRevisionResponse](
+			httpClient,
+			baseURL+PostServiceRestoreRevisionProcedure,
+			connect.WithSchema(postServiceRestoreRevisionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// postServiceClient implements PostServiceClient.
+type postServiceClient struct {
+	create          *connect.Client[v1.CreateRequest, v1.CreateResponse]
+	get             *connect.Client[v1.GetRequest, v1.GetResponse]
+	update          *connect.Client[v1.UpdateRequest, v1.UpdateResponse]
+	delete          *connect.Client[v1.DeleteRequest, v1.DeleteResponse]
+	list            *connect.Client[v1.ListRequest, v1.ListResponse]
+	restore         *connect.Client[v1.RestoreRequest, v1.RestoreResponse]
+	purge           *connect.Client[v1.PurgeRequest, v1.PurgeResponse]
+	listRevisions   *connect.Client[v1.ListRevisionsRequest, v1.ListRevisionsResponse]
+	getRevision     *connect.Client[v1.GetRevisionRequest, v1.GetRevisionResponse]
+	diffRevisions   *connect.Client[v1.DiffRevisionsRequest, v1.DiffRevisionsResponse]
+	restoreRevision *connect.Client[v1.RestoreRevisionRequest, v1.RestoreRevisionResponse]
+}
+
+// Create calls post.v1.PostService.Create.
+func (c *postServiceClient) Create(ctx context.Context, req *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls post.v1.PostService.Get.
+func (c *postServiceClient) Get(ctx context.Context, req *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Update calls post.v1.PostService.Update.
+func (c *postServiceClient) Update(ctx context.Context, req *connect.Request[v1.UpdateRequest]) (*connect.Response[v1.UpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls post.v1.PostService.Delete.
+func (c *postServiceClient) Delete(ctx context.Context, req *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// List calls post.v1.PostService.List.
+func (c *postServiceClient) List(ctx context.Context, req *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Restore calls post.v1.PostService.Restore.
+func (c *postServiceClient) Restore(ctx context.Context, req *connect.Request[v1.RestoreRequest]) (*connect.Response[v1.RestoreResponse], error) {
+	return c.restore.CallUnary(ctx, req)
+}
+
+// Purge calls post.v1.PostService.Purge.
+func (c *postServiceClient) Purge(ctx context.Context, req *connect.Request[v1.PurgeRequest]) (*connect.Response[v1.PurgeResponse], error) {
+	return c.purge.CallUnary(ctx, req)
+}
+
+// ListRevisions calls post.v1.PostService.ListRevisions.
+func (c *postServiceClient) ListRevisions(ctx context.Context, req *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error) {
+	return c.listRevisions.CallUnary(ctx, req)
+}
+
+// GetRevision calls post.v1.PostService.GetRevision.
+func (c *postServiceClient) GetRevision(ctx context.Context, req *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.GetRevisionResponse], error) {
+	return c.getRevision.CallUnary(ctx, req)
+}
+
+// DiffRevisions calls post.v1.PostService.DiffRevisions.
+func (c *postServiceClient) DiffRevisions(ctx context.Context, req *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error) {
+	return c.diffRevisions.CallUnary(ctx, req)
+}
+
+// RestoreRevision calls post.v1.PostService.RestoreRevision.
+func (c *postServiceClient) RestoreRevision(ctx context.Context, req *connect.Request[v1.RestoreRevisionRequest]) (*connect.Response[v1.RestoreRevisionResponse], error) {
+	return c.restoreRevision.CallUnary(ctx, req)
+}
+
+// PostServiceHandler is an implementation of the post.v1.PostService service.
+type PostServiceHandler interface {
+	Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error)
+	Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error)
+	Update(context.Context, *connect.Request[v1.UpdateRequest]) (*connect.Response[v1.UpdateResponse], error)
+	Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error)
+	List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error)
+	// Undeletes a soft-deleted post.
+	Restore(context.Context, *connect.Request[v1.RestoreRequest]) (*connect.Response[v1.RestoreResponse], error)
+	// Permanently removes a soft-deleted post and its revisions.
+	Purge(context.Context, *connect.Request[v1.PurgeRequest]) (*connect.Response[v1.PurgeResponse], error)
+	ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error)
+	GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.GetRevisionResponse], error)
+	DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error)
+	// Writes an earlier revision back to the post as a new revision.
+	RestoreRevision(context.Context, *connect.Request[v1.RestoreRevisionRequest]) (*connect.Response[v1.RestoreRevisionResponse], error)
+}
+
+// NewPostServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPostServiceHandler(svc PostServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	postServiceCreateHandler := connect.NewUnaryHandler(
+		PostServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(postServiceCreateMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceGetHandler := connect.NewUnaryHandler(
+		PostServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(postServiceGetMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceUpdateHandler := connect.NewUnaryHandler(
+		PostServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(postServiceUpdateMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceDeleteHandler := connect.NewUnaryHandler(
+		PostServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(postServiceDeleteMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceListHandler := connect.NewUnaryHandler(
+		PostServiceListProcedure,
+		svc.List,
+		connect.WithSchema(postServiceListMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceRestoreHandler := connect.NewUnaryHandler(
+		PostServiceRestoreProcedure,
+		svc.Restore,
+		connect.WithSchema(postServiceRestoreMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServicePurgeHandler := connect.NewUnaryHandler(
+		PostServicePurgeProcedure,
+		svc.Purge,
+		connect.WithSchema(postServicePurgeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceListRevisionsHandler := connect.NewUnaryHandler(
+		PostServiceListRevisionsProcedure,
+		svc.ListRevisions,
+		connect.WithSchema(postServiceListRevisionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceGetRevisionHandler := connect.NewUnaryHandler(
+		PostServiceGetRevisionProcedure,
+		svc.GetRevision,
+		connect.WithSchema(postServiceGetRevisionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceDiffRevisionsHandler := connect.NewUnaryHandler(
+		PostServiceDiffRevisionsProcedure,
+		svc.DiffRevisions,
+		connect.WithSchema(postServiceDiffRevisionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	postServiceRestoreRevisionHandler := connect.NewUnaryHandler(
+		PostServiceRestoreRevisionProcedure,
+		svc.RestoreRevision,
+		connect.WithSchema(postServiceRestoreRevisionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/post.v1.PostService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PostServiceCreateProcedure:
+			postServiceCreateHandler.ServeHTTP(w, r)
+		case PostServiceGetProcedure:
+			postServiceGetHandler.ServeHTTP(w, r)
+		case PostServiceUpdateProcedure:
+			postServiceUpdateHandler.ServeHTTP(w, r)
+		case PostServiceDeleteProcedure:
+			postServiceDeleteHandler.ServeHTTP(w, r)
+		case PostServiceListProcedure:
+			postServiceListHandler.ServeHTTP(w, r)
+		case PostServiceRestoreProcedure:
+			postServiceRestoreHandler.ServeHTTP(w, r)
+		case PostServicePurgeProcedure:
+			postServicePurgeHandler.ServeHTTP(w, r)
+		case PostServiceListRevisionsProcedure:
+			postServiceListRevisionsHandler.ServeHTTP(w, r)
+		case PostServiceGetRevisionProcedure:
+			postServiceGetRevisionHandler.ServeHTTP(w, r)
+		case PostServiceDiffRevisionsProcedure:
+			postServiceDiffRevisionsHandler.ServeHTTP(w, r)
+		case PostServiceRestoreRevisionProcedure:
+			postServiceRestoreRevisionHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPostServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedPostServiceHandler struct{}
+
+func (UnimplementedPostServiceHandler) Create(context.Context, *connect.Request[v1.CreateRequest]) (*connect.Response[v1.CreateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Create is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) Get(context.Context, *connect.Request[v1.GetRequest]) (*connect.Response[v1.GetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Get is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) Update(context.Context, *connect.Request[v1.UpdateRequest]) (*connect.Response[v1.UpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Update is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) Delete(context.Context, *connect.Request[v1.DeleteRequest]) (*connect.Response[v1.DeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Delete is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) List(context.Context, *connect.Request[v1.ListRequest]) (*connect.Response[v1.ListResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.List is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) Restore(context.Context, *connect.Request[v1.RestoreRequest]) (*connect.Response[v1.RestoreResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Restore is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) Purge(context.Context, *connect.Request[v1.PurgeRequest]) (*connect.Response[v1.PurgeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.Purge is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) ListRevisions(context.Context, *connect.Request[v1.ListRevisionsRequest]) (*connect.Response[v1.ListRevisionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.ListRevisions is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) GetRevision(context.Context, *connect.Request[v1.GetRevisionRequest]) (*connect.Response[v1.GetRevisionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.GetRevision is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) DiffRevisions(context.Context, *connect.Request[v1.DiffRevisionsRequest]) (*connect.Response[v1.DiffRevisionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.DiffRevisions is not implemented"))
+}
+
+func (UnimplementedPostServiceHandler) RestoreRevision(context.Context, *connect.Request[v1.RestoreRevisionRequest]) (*connect.Response[v1.RestoreRevisionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("post.v1.PostService.RestoreRevision is not implemented"))
+}
@@ -0,0 +1,90 @@
+// Package auth authenticates callers and carries their identity through the
+// request context, so the service layer can tell who is calling.
+package auth
+
+import (
+	"context"
+)
+
+// Role grants a principal a set of permissions. Roles are ordered, each one
+// includes the permissions of the roles before it.
+type Role string
+
+const (
+	// RoleReader can read posts.
+	RoleReader Role = "reader"
+	// RoleAuthor can also create posts and change the posts it owns.
+	RoleAuthor Role = "author"
+	// RoleEditor can also change the posts of others.
+	RoleEditor Role = "editor"
+	// RoleAdmin can also purge posts.
+	RoleAdmin Role = "admin"
+)
+
+// rank orders the roles, unknown roles rank below RoleReader.
+func (r Role) rank() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleAuthor:
+		return 2
+	case RoleEditor:
+		return 3
+	case RoleAdmin:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	return r.rank() > 0
+}
+
+// knownRoles returns the names that are known roles as roles, or RoleReader
+// if there are none.
+func knownRoles(names []string) []Role {
+	var roles []Role
+	for _, name := range names {
+		if role := Role(name); role.Valid() {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return []Role{RoleReader}
+	}
+	return roles
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Subject identifies the caller, e.g. the sub claim of a JWT.
+	Subject string
+	// Roles are the roles granted to the caller.
+	Roles []Role
+}
+
+// HasRole reports whether the principal was granted role or a role that
+// includes it.
+func (p *Principal) HasRole(role Role) bool {
+	for _, granted := range p.Roles {
+		if granted.Valid() && granted.rank() >= role.rank() {
+			return true
+		}
+	}
+	return false
+}
+
+type principalKey struct{}
+
+// NewContext returns a copy of ctx carrying principal.
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of the call, if it was authenticated.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
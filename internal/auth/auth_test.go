@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestPrincipal_HasRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []Role
+		role  Role
+		want  bool
+	}{
+		{name: "granted role", roles: []Role{RoleAuthor}, role: RoleAuthor, want: true},
+		{name: "higher role includes lower", roles: []Role{RoleAdmin}, role: RoleReader, want: true},
+		{name: "lower role excludes higher", roles: []Role{RoleAuthor}, role: RoleEditor, want: false},
+		{name: "any granted role counts", roles: []Role{RoleReader, RoleEditor}, role: RoleEditor, want: true},
+		{name: "unknown roles grant nothing", roles: []Role{"root"}, role: RoleReader, want: false},
+		{name: "no roles", role: RoleReader, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal := &Principal{Subject: "alice", Roles: tt.roles}
+			if got := principal.HasRole(tt.role); got != tt.want {
+				t.Errorf("HasRole(%q) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/sdoshi579/cloudbees/internal/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// publicServices can be called without credentials, so probes and tools
+// like grpcurl work against a server requiring authentication.
+var publicServices = []string{
+	healthpb.Health_ServiceDesc.ServiceName,
+	reflectionv1.ServerReflection_ServiceDesc.ServiceName,
+	reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName,
+}
+
+// apiKeyHeader carries API keys, it takes precedence over a bearer token.
+const apiKeyHeader = "x-api-key"
+
+// ErrInvalidAPIKey is returned by an APIKeyResolver for keys that are
+// unknown, revoked or expired.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKeyResolver looks up the principal an API key was issued to.
+type APIKeyResolver interface {
+	ResolveAPIKey(ctx context.Context, key string) (*Principal, error)
+}
+
+// Interceptor requires a valid "authorization: Bearer <JWT>" header, an
+// "x-api-key" header when API keys are accepted or a verified client
+// certificate when those are accepted, on every call and puts the principal
+// of the credential into the call context.
+type Interceptor struct {
+	verifier           *JWTVerifier
+	apiKeys            APIKeyResolver
+	clientCertificates bool
+	logger             *zap.Logger
+}
+
+type InterceptorConfiguration func(i *Interceptor)
+
+func NewInterceptor(verifier *JWTVerifier, logger *zap.Logger, configs ...InterceptorConfiguration) *Interceptor {
+	i := Interceptor{verifier: verifier, logger: logger}
+	for _, config := range configs {
+		config(&i)
+	}
+	return &i
+}
+
+// WithAPIKeys accepts API keys resolved by resolver.
+func WithAPIKeys(resolver APIKeyResolver) InterceptorConfiguration {
+	return func(i *Interceptor) {
+		i.apiKeys = resolver
+	}
+}
+
+// WithClientCertificates accepts client certificates verified by the TLS
+// handshake from callers that send no other credential. The subject of the
+// certificate names the principal and its organizational units that name
+// roles grant those, e.g. "OU=author".
+func WithClientCertificates() InterceptorConfiguration {
+	return func(i *Interceptor) {
+		i.clientCertificates = true
+	}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if isPublic(fullMethod) {
+		return ctx, nil
+	}
+	if key, ok := apiKey(ctx); ok && i.apiKeys != nil {
+		return i.authenticateAPIKey(ctx, key)
+	}
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if principal, ok := clientCertificate(ctx); ok && i.clientCertificates {
+			return NewContext(ctx, principal), nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	principal, err := i.verifier.Verify(token)
+	if err != nil {
+		logging.FromContext(ctx, i.logger).Info("rejected bearer token", zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return NewContext(ctx, principal), nil
+}
+
+func (i *Interceptor) authenticateAPIKey(ctx context.Context, key string) (context.Context, error) {
+	principal, err := i.apiKeys.ResolveAPIKey(ctx, key)
+	switch {
+	case errors.Is(err, ErrInvalidAPIKey):
+		logging.FromContext(ctx, i.logger).Info("rejected api key", zap.Error(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		logging.FromContext(ctx, i.logger).Error("error in resolving api key", zap.Error(err))
+		return nil, status.Error(codes.Internal, "error in checking API key")
+	}
+	return NewContext(ctx, principal), nil
+}
+
+func isPublic(fullMethod string) bool {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	for _, public := range publicServices {
+		if service == public {
+			return true
+		}
+	}
+	return false
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") && token != "" {
+			return strings.TrimSpace(token), true
+		}
+	}
+	return "", false
+}
+
+func apiKey(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(apiKeyHeader) {
+		if key := strings.TrimSpace(value); key != "" {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// clientCertificate returns the principal of the client certificate verified
+// by the TLS handshake of the call, if there is one.
+func clientCertificate(ctx context.Context) (*Principal, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return nil, false
+	}
+	subject := tlsInfo.State.VerifiedChains[0][0].Subject
+	return &Principal{Subject: subject.String(), Roles: knownRoles(subject.OrganizationalUnit)}, true
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterceptor_Unary(t *testing.T) {
+	verifier, err := NewJWTVerifier(WithHS256Secret(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := sign(t, jwt.SigningMethodHS256, testSecret, "", jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	const postMethod = "/post.v1.PostService/Delete"
+	healthMethod := "/" + healthpb.Health_ServiceDesc.ServiceName + "/Check"
+
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		wantCode      codes.Code
+		wantMessage   string
+		wantSubject   string
+	}{
+		{name: "valid token", method: postMethod, authorization: "Bearer " + valid, wantCode: codes.OK, wantSubject: "alice"},
+		{name: "lowercase scheme", method: postMethod, authorization: "bearer " + valid, wantCode: codes.OK, wantSubject: "alice"},
+		{name: "missing token", method: postMethod, wantCode: codes.Unauthenticated},
+		{name: "basic auth", method: postMethod, authorization: "Basic YWxpY2U6c2VjcmV0", wantCode: codes.Unauthenticated},
+		{name: "invalid token", method: postMethod, authorization: "Bearer " + valid + "x", wantCode: codes.Unauthenticated,
+			wantMessage: "invalid bearer token"},
+		{name: "health checks are public", method: healthMethod, wantCode: codes.OK},
+	}
+	interceptor := NewInterceptor(verifier, zap.NewNop()).Unary()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+			var subject string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req any) (any, error) {
+					if principal, ok := FromContext(ctx); ok {
+						subject = principal.Subject
+					}
+					return nil, nil
+				})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if message := status.Convert(err).Message(); tt.wantMessage != "" && message != tt.wantMessage {
+				t.Errorf("interceptor message = %q, want %q", message, tt.wantMessage)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("principal subject = %q, want %q", subject, tt.wantSubject)
+			}
+		})
+	}
+}
+
+// apiKeyResolverFunc resolves API keys with a function.
+type apiKeyResolverFunc func(ctx context.Context, key string) (*Principal, error)
+
+func (f apiKeyResolverFunc) ResolveAPIKey(ctx context.Context, key string) (*Principal, error) {
+	return f(ctx, key)
+}
+
+func TestInterceptor_Unary_apiKey(t *testing.T) {
+	verifier, err := NewJWTVerifier(WithHS256Secret(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := apiKeyResolverFunc(func(ctx context.Context, key string) (*Principal, error) {
+		switch key {
+		case "cbk_importer":
+			return &Principal{Subject: "apikey:importer", Roles: []Role{RoleAuthor}}, nil
+		case "cbk_broken":
+			return nil, errors.New("database is locked")
+		default:
+			return nil, ErrInvalidAPIKey
+		}
+	})
+	valid := sign(t, jwt.SigningMethodHS256, testSecret, "", jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name        string
+		md          metadata.MD
+		resolver    APIKeyResolver
+		wantCode    codes.Code
+		wantSubject string
+	}{
+		{name: "valid key", md: metadata.Pairs("x-api-key", "cbk_importer"), resolver: resolver,
+			wantCode: codes.OK, wantSubject: "apikey:importer"},
+		{name: "invalid key", md: metadata.Pairs("x-api-key", "cbk_revoked"), resolver: resolver,
+			wantCode: codes.Unauthenticated},
+		{name: "key takes precedence over a token", resolver: resolver, wantCode: codes.Unauthenticated,
+			md: metadata.Pairs("x-api-key", "cbk_revoked", "authorization", "Bearer "+valid)},
+		{name: "resolver failure is not reported as a bad key", md: metadata.Pairs("x-api-key", "cbk_broken"),
+			resolver: resolver, wantCode: codes.Internal},
+		{name: "keys are ignored unless accepted", md: metadata.Pairs("x-api-key", "cbk_importer"),
+			wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configs []InterceptorConfiguration
+			if tt.resolver != nil {
+				configs = append(configs, WithAPIKeys(tt.resolver))
+			}
+			interceptor := NewInterceptor(verifier, zap.NewNop(), configs...).Unary()
+			var subject string
+			_, err := interceptor(metadata.NewIncomingContext(context.Background(), tt.md), nil,
+				&grpc.UnaryServerInfo{FullMethod: "/post.v1.PostService/Create"},
+				func(ctx context.Context, req any) (any, error) {
+					if principal, ok := FromContext(ctx); ok {
+						subject = principal.Subject
+					}
+					return nil, nil
+				})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("principal subject = %q, want %q", subject, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestInterceptor_Unary_clientCertificate(t *testing.T) {
+	verifier, err := NewJWTVerifier(WithHS256Secret(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := sign(t, jwt.SigningMethodHS256, testSecret, "", jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "importer", OrganizationalUnit: []string{"author", "batch"}}}
+	verified := credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{certificate},
+		VerifiedChains:   [][]*x509.Certificate{{certificate}},
+	}}
+	unverified := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}}
+
+	tests := []struct {
+		name     string
+		authInfo credentials.AuthInfo
+		md       metadata.MD
+		accept   bool
+		wantCode codes.Code
+		want     *Principal
+	}{
+		{name: "verified certificate", authInfo: verified, accept: true, wantCode: codes.OK,
+			want: &Principal{Subject: "CN=importer,OU=author+OU=batch", Roles: []Role{RoleAuthor}}},
+		{name: "token takes precedence over a certificate", authInfo: verified, accept: true,
+			md: metadata.Pairs("authorization", "Bearer "+valid), wantCode: codes.OK,
+			want: &Principal{Subject: "alice", Roles: []Role{RoleReader}}},
+		{name: "unverified certificate", authInfo: unverified, accept: true, wantCode: codes.Unauthenticated},
+		{name: "certificates are ignored unless accepted", authInfo: verified, wantCode: codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configs []InterceptorConfiguration
+			if tt.accept {
+				configs = append(configs, WithClientCertificates())
+			}
+			interceptor := NewInterceptor(verifier, zap.NewNop(), configs...).Unary()
+			ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), tt.md), &peer.Peer{AuthInfo: tt.authInfo})
+			var got *Principal
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/post.v1.PostService/Create"},
+				func(ctx context.Context, req any) (any, error) {
+					got, _ = FromContext(ctx)
+					return nil, nil
+				})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("interceptor code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("principal = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
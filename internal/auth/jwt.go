@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"time"
+)
+
+const (
+	// MinHS256SecretLength is the shortest secret accepted for HS256, the
+	// size of its hash output as RFC 7518 requires.
+	MinHS256SecretLength = 32
+	// leeway absorbs clock skew between the token issuer and the server.
+	leeway = 30 * time.Second
+)
+
+// JWTVerifier validates bearer tokens signed with HS256 or RS256.
+type JWTVerifier struct {
+	hs256Secret []byte
+	rsaKeys     map[string]*rsa.PublicKey
+	issuer      string
+	audience    string
+	now         func() time.Time
+	parser      *jwt.Parser
+}
+
+type JWTVerifierConfiguration func(v *JWTVerifier)
+
+// NewJWTVerifier returns a verifier accepting tokens signed by any of the
+// configured keys, at least one is required.
+func NewJWTVerifier(configs ...JWTVerifierConfiguration) (*JWTVerifier, error) {
+	v := JWTVerifier{now: time.Now}
+	for _, config := range configs {
+		config(&v)
+	}
+
+	var methods []string
+	if v.hs256Secret != nil {
+		if len(v.hs256Secret) < MinHS256SecretLength {
+			return nil, fmt.Errorf("HS256 secret must be at least %d bytes", MinHS256SecretLength)
+		}
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(v.rsaKeys) != 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no HS256 secret or RS256 keys configured")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(leeway),
+		jwt.WithTimeFunc(v.now),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		options = append(options, jwt.WithAudience(v.audience))
+	}
+	v.parser = jwt.NewParser(options...)
+	return &v, nil
+}
+
+// WithHS256Secret accepts HS256 tokens signed with secret.
+func WithHS256Secret(secret []byte) JWTVerifierConfiguration {
+	return func(v *JWTVerifier) {
+		v.hs256Secret = secret
+	}
+}
+
+// WithRS256Keys accepts RS256 tokens signed by one of keys, looked up by the
+// kid header of the token. A token without kid is accepted when there is a
+// single key.
+func WithRS256Keys(keys map[string]*rsa.PublicKey) JWTVerifierConfiguration {
+	return func(v *JWTVerifier) {
+		v.rsaKeys = keys
+	}
+}
+
+// WithIssuer requires the iss claim to equal issuer.
+func WithIssuer(issuer string) JWTVerifierConfiguration {
+	return func(v *JWTVerifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience requires the aud claim to contain audience.
+func WithAudience(audience string) JWTVerifierConfiguration {
+	return func(v *JWTVerifier) {
+		v.audience = audience
+	}
+}
+
+// withClock replaces time.Now for validating exp and nbf.
+func withClock(now func() time.Time) JWTVerifierConfiguration {
+	return func(v *JWTVerifier) {
+		v.now = now
+	}
+}
+
+// claims are the registered claims plus the roles granted to the subject.
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Verify checks the signature and the claims of token and returns the
+// principal named by its sub claim. The principal gets the known roles of
+// the roles claim, or RoleReader if the token grants none.
+func (v *JWTVerifier) Verify(token string) (*Principal, error) {
+	var claims claims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.key); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no sub claim")
+	}
+	return &Principal{Subject: claims.Subject, Roles: knownRoles(claims.Roles)}, nil
+}
+
+func (v *JWTVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		return v.hs256Secret, nil
+	case *jwt.SigningMethodRSA:
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.rsaKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(v.rsaKeys) == 1 {
+			for _, key := range v.rsaKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+// jsonWebKey holds the members of an RFC 7517 JSON Web Key used for RSA
+// signature keys.
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// LoadJWKS reads the RSA signature keys of the JSON Web Key Set at path,
+// keyed by their kid. Keys of other types or meant for other uses are skipped.
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS: %w", err)
+	}
+	keys, err := parseJWKS(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWKS %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+func parseJWKS(raw []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for i, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") ||
+			(jwk.Algorithm != "" && jwk.Algorithm != jwt.SigningMethodRS256.Alg()) {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: modulus: %w", i, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+		if err != nil {
+			return nil, fmt.Errorf("key %d: exponent: %w", i, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %d: invalid exponent", i)
+		}
+		if _, ok := keys[jwk.KeyID]; ok {
+			return nil, fmt.Errorf("key %d: duplicate kid %q", i, jwk.KeyID)
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signature keys")
+	}
+	return keys, nil
+}
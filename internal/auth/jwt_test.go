@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testSecret = []byte(strings.Repeat("s", MinHS256SecretLength))
+	testNow    = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// writeJWKS writes the public halves of keys, keyed by kid, as a JWKS file.
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			KeyType:   "RSA",
+			KeyID:     kid,
+			Use:       "sig",
+			Algorithm: "RS256",
+			Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	raw, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "alice",
+		"iss": "https://issuer.example",
+		"aud": "posts",
+		"exp": testNow.Add(time.Hour).Unix(),
+	}
+}
+
+func withClaim(name string, value any) jwt.MapClaims {
+	claims := validClaims()
+	if value == nil {
+		delete(claims, name)
+	} else {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestJWTVerifier_Verify(t *testing.T) {
+	rsaKey, otherKey := newRSAKey(t), newRSAKey(t)
+	keys, err := LoadJWKS(writeJWKS(t, map[string]*rsa.PrivateKey{"key-1": rsaKey}))
+	if err != nil {
+		t.Fatalf("LoadJWKS() error = %v", err)
+	}
+	verifier, err := NewJWTVerifier(WithHS256Secret(testSecret), WithRS256Keys(keys),
+		WithIssuer("https://issuer.example"), WithAudience("posts"), withClock(func() time.Time { return testNow }))
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, testSecret, "", validClaims())},
+		{name: "RS256 with kid", token: sign(t, jwt.SigningMethodRS256, rsaKey, "key-1", validClaims())},
+		{name: "RS256 without kid and a single key", token: sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims())},
+		{
+			name:    "wrong HS256 secret",
+			token:   sign(t, jwt.SigningMethodHS256, []byte(strings.Repeat("x", 32)), "", validClaims()),
+			wantErr: "signature is invalid",
+		},
+		{
+			name:    "RS256 signed by an unknown key",
+			token:   sign(t, jwt.SigningMethodRS256, otherKey, "key-1", validClaims()),
+			wantErr: "verification error",
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, jwt.SigningMethodRS256, rsaKey, "key-2", validClaims()),
+			wantErr: `unknown key id "key-2"`,
+		},
+		{
+			name:    "HS384 is not accepted",
+			token:   sign(t, jwt.SigningMethodHS384, testSecret, "", validClaims()),
+			wantErr: "signing method HS384 is invalid",
+		},
+		{
+			name:    "expired",
+			token:   sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("exp", testNow.Add(-time.Minute).Unix())),
+			wantErr: "token is expired",
+		},
+		{
+			name:    "without exp",
+			token:   sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("exp", nil)),
+			wantErr: "exp claim is required",
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("iss", "https://evil.example")),
+			wantErr: "token has invalid issuer",
+		},
+		{
+			name:    "wrong audience",
+			token:   sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("aud", "comments")),
+			wantErr: "token has invalid audience",
+		},
+		{
+			name:    "without sub",
+			token:   sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("sub", nil)),
+			wantErr: "token has no sub claim",
+		},
+		{name: "malformed", token: "not-a-jwt", wantErr: "token is malformed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(tt.token)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if principal.Subject != "alice" {
+				t.Errorf("Verify() subject = %q, want alice", principal.Subject)
+			}
+		})
+	}
+}
+
+func TestNewJWTVerifier_errors(t *testing.T) {
+	if _, err := NewJWTVerifier(); err == nil {
+		t.Error("NewJWTVerifier() without keys succeeded")
+	}
+	if _, err := NewJWTVerifier(WithHS256Secret([]byte("short"))); err == nil {
+		t.Error("NewJWTVerifier() with a short secret succeeded")
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	tests := []struct {
+		name    string
+		jwks    string
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "skips keys that cannot verify RS256",
+			jwks: `{"keys": [
+				{"kty": "RSA", "kid": "a", "n": "AQAB", "e": "AQAB"},
+				{"kty": "RSA", "kid": "b", "use": "enc", "n": "AQAB", "e": "AQAB"},
+				{"kty": "RSA", "kid": "c", "alg": "PS256", "n": "AQAB", "e": "AQAB"},
+				{"kty": "EC", "kid": "d", "crv": "P-256"}
+			]}`,
+			wantLen: 1,
+		},
+		{name: "no usable key", jwks: `{"keys": [{"kty": "EC", "kid": "d"}]}`, wantErr: true},
+		{name: "duplicate kid", jwks: `{"keys": [{"kty": "RSA", "kid": "a", "n": "AQAB", "e": "AQAB"},
+			{"kty": "RSA", "kid": "a", "n": "AQAB", "e": "AQAB"}]}`, wantErr: true},
+		{name: "bad modulus", jwks: `{"keys": [{"kty": "RSA", "n": "!!", "e": "AQAB"}]}`, wantErr: true},
+		{name: "not json", jwks: `keys`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := parseJWKS([]byte(tt.jwks))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseJWKS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(keys) != tt.wantLen {
+				t.Errorf("parseJWKS() returned %d keys, want %d", len(keys), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestJWTVerifier_Verify_roles(t *testing.T) {
+	verifier, err := NewJWTVerifier(WithHS256Secret(testSecret), withClock(func() time.Time { return testNow }))
+	if err != nil {
+		t.Fatalf("NewJWTVerifier() error = %v", err)
+	}
+	tests := []struct {
+		name  string
+		roles any
+		want  []Role
+	}{
+		{name: "without roles claim", want: []Role{RoleReader}},
+		{name: "known roles", roles: []string{"author", "editor"}, want: []Role{RoleAuthor, RoleEditor}},
+		{name: "unknown roles are ignored", roles: []string{"owner", "admin"}, want: []Role{RoleAdmin}},
+		{name: "only unknown roles", roles: []string{"superuser"}, want: []Role{RoleReader}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, testSecret, "", withClaim("roles", tt.roles)))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if len(principal.Roles) != len(tt.want) {
+				t.Fatalf("Verify() roles = %v, want %v", principal.Roles, tt.want)
+			}
+			for i := range tt.want {
+				if principal.Roles[i] != tt.want[i] {
+					t.Errorf("Verify() roles = %v, want %v", principal.Roles, tt.want)
+				}
+			}
+		})
+	}
+}
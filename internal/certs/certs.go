@@ -0,0 +1,213 @@
+// Package certs serves TLS certificates read from PEM files and picks up
+// changes to the files without restarting the process, e.g. when a
+// certificate is renewed by cert-manager or certbot.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+const defaultInterval = 30 * time.Second
+
+// bundle is what was loaded from the files at one point in time. The raw
+// file contents are kept to tell whether the files changed.
+type bundle struct {
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	files       [][]byte
+}
+
+// Reloader holds the certificate, and optionally the client CA bundle, read
+// from files, and reloads them when the files change.
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+	logger       *zap.Logger
+
+	current atomic.Pointer[bundle]
+}
+
+type ReloaderConfiguration func(r *Reloader)
+
+// NewReloader reads the certificate chain and private key from certFile and
+// keyFile. It fails when they, or the client CA bundle, cannot be loaded.
+func NewReloader(certFile, keyFile string, configs ...ReloaderConfiguration) (*Reloader, error) {
+	r := Reloader{certFile: certFile, keyFile: keyFile, interval: defaultInterval, logger: zap.NewNop()}
+	for _, config := range configs {
+		config(&r)
+	}
+	b, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.current.Store(b)
+	return &r, nil
+}
+
+// WithClientCAFile verifies client certificates against the PEM bundle in
+// file. Clients that present no certificate are still accepted.
+func WithClientCAFile(file string) ReloaderConfiguration {
+	return func(r *Reloader) {
+		r.clientCAFile = file
+	}
+}
+
+// WithInterval sets how often the files are checked for changes.
+func WithInterval(interval time.Duration) ReloaderConfiguration {
+	return func(r *Reloader) {
+		r.interval = interval
+	}
+}
+
+func WithLogger(logger *zap.Logger) ReloaderConfiguration {
+	return func(r *Reloader) {
+		r.logger = logger
+	}
+}
+
+// Run checks the files for changes every interval until ctx is done.
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.ReloadNow()
+		}
+	}
+}
+
+// ReloadNow reloads the files if they changed. Files that fail to load, e.g.
+// because only the certificate was replaced so far, are logged and the
+// previous certificate is served until the next successful reload.
+func (r *Reloader) ReloadNow() {
+	files, err := r.read()
+	if err != nil {
+		r.logger.Error("error in reading TLS files, keeping the current certificate", zap.Error(err))
+		return
+	}
+	if equalFiles(files, r.current.Load().files) {
+		return
+	}
+	b, err := r.parse(files)
+	if err != nil {
+		r.logger.Error("error in reloading TLS files, keeping the current certificate", zap.Error(err))
+		return
+	}
+	r.current.Store(b)
+	r.logger.Info("reloaded TLS certificate", zap.String("subject", b.certificate.Leaf.Subject.String()),
+		zap.Time("notAfter", b.certificate.Leaf.NotAfter))
+}
+
+// ServerConfig returns a TLS configuration that serves the current
+// certificate, and verifies client certificates when a client CA bundle is
+// set, on every new connection. nextProtos are the ALPN protocols offered.
+func (r *Reloader) ServerConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			b := r.current.Load()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   nextProtos,
+				Certificates: []tls.Certificate{*b.certificate},
+			}
+			if b.clientCAs != nil {
+				config.ClientCAs = b.clientCAs
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			return config, nil
+		},
+	}
+}
+
+// LoopbackClientConfig returns a TLS configuration for connecting to this
+// process, such as the REST gateway calling the gRPC server. It accepts
+// exactly the certificate currently served rather than checking its names,
+// which rarely cover the loopback address.
+func (r *Reloader) LoopbackClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// VerifyConnection pins the certificate instead
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			served := r.current.Load().certificate.Certificate[0]
+			if len(state.PeerCertificates) == 0 || !bytes.Equal(state.PeerCertificates[0].Raw, served) {
+				return errors.New("certs: loopback peer does not serve the current certificate")
+			}
+			return nil
+		},
+	}
+}
+
+func (r *Reloader) load() (*bundle, error) {
+	files, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	return r.parse(files)
+}
+
+// read returns the contents of the certificate, key and client CA files.
+func (r *Reloader) read() ([][]byte, error) {
+	names := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		names = append(names, r.clientCAFile)
+	}
+	files := make([][]byte, len(names))
+	for i, name := range names {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = content
+	}
+	return files, nil
+}
+
+func (r *Reloader) parse(files [][]byte) (*bundle, error) {
+	certificate, err := tls.X509KeyPair(files[0], files[1])
+	if err != nil {
+		return nil, fmt.Errorf("loading %s and %s: %w", r.certFile, r.keyFile, err)
+	}
+	if certificate.Leaf == nil {
+		if certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", r.certFile, err)
+		}
+	}
+	b := &bundle{certificate: &certificate, files: files}
+	if r.clientCAFile != "" {
+		b.clientCAs = x509.NewCertPool()
+		if !b.clientCAs.AppendCertsFromPEM(files[2]) {
+			return nil, fmt.Errorf("loading %s: no PEM encoded certificates found", r.clientCAFile)
+		}
+	}
+	return b, nil
+}
+
+func equalFiles(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,265 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// issue creates a certificate for subject signed by parent, or a self-signed
+// CA when parent is nil.
+func issue(t *testing.T, subject string, parent *tls.Certificate) *tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: subject},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, any(key)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.Leaf, parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// writePEM writes the certificate and key of certificate to certFile and keyFile.
+func writePEM(t *testing.T, certificate *tls.Certificate, certFile, keyFile string) {
+	key, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]})
+	if err := os.WriteFile(certFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key})
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects a client using clientConfig to a server using
+// serverConfig and returns the state of both ends.
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (server, client tls.ConnectionState, err error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	type result struct {
+		state tls.ConnectionState
+		err   error
+	}
+	accepted := make(chan result, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			accepted <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		tlsConn := tls.Server(conn, serverConfig)
+		err = tlsConn.Handshake()
+		accepted <- result{state: tlsConn.ConnectionState(), err: err}
+	}()
+
+	tlsClient, clientErr := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+	if clientErr == nil {
+		defer tlsClient.Close()
+		client = tlsClient.ConnectionState()
+	}
+	serverResult := <-accepted
+	if serverResult.err != nil {
+		return tls.ConnectionState{}, tls.ConnectionState{}, serverResult.err
+	}
+	if clientErr != nil {
+		return tls.ConnectionState{}, tls.ConnectionState{}, clientErr
+	}
+	return serverResult.state, client, nil
+}
+
+type files struct {
+	cert, key, caCert, caKey string
+}
+
+func newFiles(t *testing.T) files {
+	dir := t.TempDir()
+	return files{
+		cert:   filepath.Join(dir, "server.pem"),
+		key:    filepath.Join(dir, "server-key.pem"),
+		caCert: filepath.Join(dir, "ca.pem"),
+		caKey:  filepath.Join(dir, "ca-key.pem"),
+	}
+}
+
+func TestNewReloader_errors(t *testing.T) {
+	f := newFiles(t)
+	writePEM(t, issue(t, "server", nil), f.cert, f.key)
+	other := newFiles(t)
+	writePEM(t, issue(t, "other", nil), other.cert, other.key)
+
+	tests := []struct {
+		name    string
+		cert    string
+		key     string
+		configs []ReloaderConfiguration
+	}{
+		{name: "missing certificate", cert: filepath.Join(t.TempDir(), "missing.pem"), key: f.key},
+		{name: "key of another certificate", cert: f.cert, key: other.key},
+		{name: "missing client ca", cert: f.cert, key: f.key,
+			configs: []ReloaderConfiguration{WithClientCAFile(filepath.Join(t.TempDir(), "missing.pem"))}},
+		{name: "client ca without certificates", cert: f.cert, key: f.key,
+			configs: []ReloaderConfiguration{WithClientCAFile(f.key)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReloader(tt.cert, tt.key, tt.configs...); err == nil {
+				t.Error("NewReloader() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestReloader_ReloadNow(t *testing.T) {
+	f := newFiles(t)
+	first := issue(t, "first", nil)
+	writePEM(t, first, f.cert, f.key)
+	reloader, err := NewReloader(f.cert, f.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := func() string {
+		t.Helper()
+		_, client, err := handshake(t, reloader.ServerConfig(), &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			t.Fatalf("handshake error = %v", err)
+		}
+		return client.PeerCertificates[0].Subject.CommonName
+	}
+	if got := served(); got != "first" {
+		t.Errorf("served %q before reloading, want first", got)
+	}
+
+	writePEM(t, issue(t, "second", nil), f.cert, f.key)
+	if got := served(); got != "first" {
+		t.Errorf("served %q before ReloadNow, want first", got)
+	}
+	reloader.ReloadNow()
+	if got := served(); got != "second" {
+		t.Errorf("served %q after ReloadNow, want second", got)
+	}
+
+	// a certificate without its new key keeps the previous pair
+	third := newFiles(t)
+	writePEM(t, issue(t, "third", nil), third.cert, third.key)
+	content, err := os.ReadFile(third.cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(f.cert, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reloader.ReloadNow()
+	if got := served(); got != "second" {
+		t.Errorf("served %q after a mismatched reload, want second", got)
+	}
+}
+
+func TestReloader_clientCertificates(t *testing.T) {
+	f := newFiles(t)
+	ca := issue(t, "ca", nil)
+	writePEM(t, ca, f.caCert, f.caKey)
+	writePEM(t, issue(t, "server", ca), f.cert, f.key)
+	reloader, err := NewReloader(f.cert, f.key, WithClientCAFile(f.caCert))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		certificates []tls.Certificate
+		wantErr      bool
+		wantVerified bool
+	}{
+		{name: "certificate signed by the ca", certificates: []tls.Certificate{*issue(t, "client", ca)}, wantVerified: true},
+		{name: "no certificate"},
+		{name: "certificate of another ca", certificates: []tls.Certificate{*issue(t, "client", issue(t, "other", nil))},
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConfig := &tls.Config{
+				InsecureSkipVerify: true,
+				// send the certificate even when the server does not list its CA
+				GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+					if len(tt.certificates) == 0 {
+						return &tls.Certificate{}, nil
+					}
+					return &tt.certificates[0], nil
+				},
+			}
+			server, _, err := handshake(t, reloader.ServerConfig(), clientConfig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("handshake error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if verified := len(server.VerifiedChains) != 0; verified != tt.wantVerified {
+				t.Errorf("client certificate verified = %v, want %v", verified, tt.wantVerified)
+			}
+		})
+	}
+}
+
+func TestReloader_LoopbackClientConfig(t *testing.T) {
+	f := newFiles(t)
+	writePEM(t, issue(t, "server", nil), f.cert, f.key)
+	reloader, err := NewReloader(f.cert, f.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := handshake(t, reloader.ServerConfig(), reloader.LoopbackClientConfig()); err != nil {
+		t.Errorf("handshake with the served certificate error = %v", err)
+	}
+
+	impostor := newFiles(t)
+	writePEM(t, issue(t, "server", nil), impostor.cert, impostor.key)
+	other, err := NewReloader(impostor.cert, impostor.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := handshake(t, other.ServerConfig(), reloader.LoopbackClientConfig()); err == nil {
+		t.Error("handshake with another certificate succeeded, want an error")
+	}
+}
@@ -0,0 +1,394 @@
+// Package config loads the server configuration. Every setting has a
+// default which can be overridden, in increasing order of precedence, by a
+// YAML or TOML file, a CLOUDBEES_* environment variable and a command line
+// flag.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	"github.com/sdoshi579/cloudbees/internal/tracing"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envPrefix = "CLOUDBEES_"
+	// ConfigFlag names the flag, and with envPrefix the environment
+	// variable, pointing at the config file.
+	ConfigFlag = "config"
+)
+
+type Config struct {
+	Server    ServerConfig    `yaml:"server" toml:"server"`
+	Database  DatabaseConfig  `yaml:"database" toml:"database"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Retention RetentionConfig `yaml:"retention" toml:"retention"`
+	Auth      AuthConfig      `yaml:"auth" toml:"auth"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
+}
+
+type ServerConfig struct {
+	// Address is the host:port the gRPC server listens on.
+	Address string `yaml:"address" toml:"address"`
+	// HTTPAddress is the host:port serving the REST and Connect APIs and the
+	// /healthz and /readyz probes.
+	HTTPAddress string `yaml:"http_address" toml:"http_address"`
+	// HealthCheckInterval is how often readiness is re-evaluated.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" toml:"health_check_interval"`
+	// Reflection enables gRPC server reflection and the /descriptors endpoint.
+	Reflection bool `yaml:"reflection" toml:"reflection"`
+	// ShutdownTimeout bounds how long in-flight requests are drained on shutdown.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	TLS             TLSConfig     `yaml:"tls" toml:"tls"`
+}
+
+type TLSConfig struct {
+	// CertFile and KeyFile hold the PEM encoded certificate chain and private
+	// key served on both addresses. TLS is off unless both are set.
+	CertFile string `yaml:"cert_file" toml:"cert_file"`
+	KeyFile  string `yaml:"key_file" toml:"key_file"`
+	// ClientCAFile is a PEM bundle verifying client certificates, which then
+	// authenticate the caller.
+	ClientCAFile string `yaml:"client_ca_file" toml:"client_ca_file"`
+	// ReloadInterval is how often the files are checked for changes.
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval"`
+}
+
+// Enabled reports whether the servers use TLS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+type DatabaseConfig struct {
+	Driver string `yaml:"driver" toml:"driver"`
+	DSN    string `yaml:"dsn" toml:"dsn"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level" toml:"level"`
+	// Format is json or console.
+	Format string `yaml:"format" toml:"format"`
+}
+
+type RetentionConfig struct {
+	// PurgeAfter is how long soft-deleted posts are kept, 0 keeps them forever.
+	PurgeAfter    time.Duration `yaml:"purge_after" toml:"purge_after"`
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval"`
+}
+
+type AuthConfig struct {
+	// Enabled requires a valid JWT bearer token on every call except health
+	// checks and reflection. HS256Secret, JWKSFile or both must be set.
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// HS256Secret verifies HS256 tokens.
+	HS256Secret string `yaml:"hs256_secret" toml:"hs256_secret"`
+	// JWKSFile is a JSON Web Key Set whose RSA keys verify RS256 tokens.
+	JWKSFile string `yaml:"jwks_file" toml:"jwks_file"`
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string `yaml:"issuer" toml:"issuer"`
+	Audience string `yaml:"audience" toml:"audience"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp.
+	Exporter string `yaml:"exporter" toml:"exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string `yaml:"endpoint" toml:"endpoint"`
+	// Insecure connects to the collector without TLS.
+	Insecure bool `yaml:"insecure" toml:"insecure"`
+	// SampleRatio is the fraction of new traces recorded, between 0 and 1.
+	SampleRatio float64 `yaml:"sample_ratio" toml:"sample_ratio"`
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:             ":8080",
+			HTTPAddress:         ":8081",
+			HealthCheckInterval: 10 * time.Second,
+			Reflection:          true,
+			ShutdownTimeout:     30 * time.Second,
+			TLS:                 TLSConfig{ReloadInterval: 30 * time.Second},
+		},
+		Database: DatabaseConfig{Driver: database.DriverSQLite, DSN: "file:cloudbees.db"},
+		Log:      LogConfig{Level: "info", Format: "json"},
+		Retention: RetentionConfig{
+			PurgeAfter:    30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Auth:    AuthConfig{Enabled: true},
+		Tracing: TracingConfig{Exporter: tracing.ExporterNone, Endpoint: "localhost:4317", SampleRatio: 1},
+	}
+}
+
+// setting binds a config field to its file key, environment variable and flag.
+type setting struct {
+	// key is the dotted path of the field in the config file.
+	key   string
+	flag  string
+	usage string
+	field func(*Config) any
+}
+
+var settings = []setting{
+	{key: "server.address", flag: "address", usage: "host:port the gRPC server listens on",
+		field: func(c *Config) any { return &c.Server.Address }},
+	{key: "server.http_address", flag: "http-address", usage: "host:port serving the REST and Connect APIs and the HTTP health probes",
+		field: func(c *Config) any { return &c.Server.HTTPAddress }},
+	{key: "server.health_check_interval", flag: "health-check-interval", usage: "how often readiness is re-evaluated",
+		field: func(c *Config) any { return &c.Server.HealthCheckInterval }},
+	{key: "server.reflection", flag: "reflection", usage: "serve gRPC reflection and the compiled descriptors",
+		field: func(c *Config) any { return &c.Server.Reflection }},
+	{key: "server.shutdown_timeout", flag: "shutdown-timeout", usage: "how long in-flight requests may finish on shutdown",
+		field: func(c *Config) any { return &c.Server.ShutdownTimeout }},
+	{key: "server.tls.cert_file", flag: "tls-cert-file", usage: "PEM certificate chain served over TLS, enables TLS with --tls-key-file",
+		field: func(c *Config) any { return &c.Server.TLS.CertFile }},
+	{key: "server.tls.key_file", flag: "tls-key-file", usage: "PEM private key of the TLS certificate",
+		field: func(c *Config) any { return &c.Server.TLS.KeyFile }},
+	{key: "server.tls.client_ca_file", flag: "tls-client-ca-file", usage: "PEM CA bundle verifying client certificates",
+		field: func(c *Config) any { return &c.Server.TLS.ClientCAFile }},
+	{key: "server.tls.reload_interval", flag: "tls-reload-interval", usage: "how often the TLS files are checked for changes",
+		field: func(c *Config) any { return &c.Server.TLS.ReloadInterval }},
+	{key: "database.driver", flag: "db-driver", usage: "database driver: sqlite3, postgres or mysql",
+		field: func(c *Config) any { return &c.Database.Driver }},
+	{key: "database.dsn", flag: "db-dsn", usage: "data source name passed to the database driver",
+		field: func(c *Config) any { return &c.Database.DSN }},
+	{key: "log.level", flag: "log-level", usage: "log level: debug, info, warn or error",
+		field: func(c *Config) any { return &c.Log.Level }},
+	{key: "log.format", flag: "log-format", usage: "log format: json or console",
+		field: func(c *Config) any { return &c.Log.Format }},
+	{key: "retention.purge_after", flag: "purge-after", usage: "purge posts soft-deleted longer than this, 0 disables purging",
+		field: func(c *Config) any { return &c.Retention.PurgeAfter }},
+	{key: "retention.purge_interval", flag: "purge-interval", usage: "how often to look for posts to purge",
+		field: func(c *Config) any { return &c.Retention.PurgeInterval }},
+	{key: "auth.enabled", flag: "auth-enabled", usage: "require a JWT bearer token on every call",
+		field: func(c *Config) any { return &c.Auth.Enabled }},
+	{key: "auth.hs256_secret", flag: "auth-hs256-secret", usage: "secret verifying HS256 tokens, prefer the environment variable",
+		field: func(c *Config) any { return &c.Auth.HS256Secret }},
+	{key: "auth.jwks_file", flag: "auth-jwks-file", usage: "JSON Web Key Set file verifying RS256 tokens",
+		field: func(c *Config) any { return &c.Auth.JWKSFile }},
+	{key: "auth.issuer", flag: "auth-issuer", usage: "required iss claim of tokens",
+		field: func(c *Config) any { return &c.Auth.Issuer }},
+	{key: "auth.audience", flag: "auth-audience", usage: "required aud claim of tokens",
+		field: func(c *Config) any { return &c.Auth.Audience }},
+	{key: "tracing.exporter", flag: "tracing-exporter", usage: "where spans are exported: none, stdout or otlp",
+		field: func(c *Config) any { return &c.Tracing.Exporter }},
+	{key: "tracing.endpoint", flag: "tracing-endpoint", usage: "host:port of the OTLP gRPC collector",
+		field: func(c *Config) any { return &c.Tracing.Endpoint }},
+	{key: "tracing.insecure", flag: "tracing-insecure", usage: "connect to the OTLP collector without TLS",
+		field: func(c *Config) any { return &c.Tracing.Insecure }},
+	{key: "tracing.sample_ratio", flag: "tracing-sample-ratio", usage: "fraction of new traces recorded, between 0 and 1",
+		field: func(c *Config) any { return &c.Tracing.SampleRatio }},
+}
+
+// envName returns the environment variable for a key, e.g. CLOUDBEES_DATABASE_DSN.
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(strings.NewReplacer(".", "_").Replace(key))
+}
+
+// RegisterFlags adds a flag for every setting, and one for the config file, to flags.
+func RegisterFlags(flags *pflag.FlagSet) {
+	defaults := Default()
+	flags.String(ConfigFlag, "", "path to a YAML or TOML config file, also read from "+envName(ConfigFlag))
+	for _, s := range settings {
+		usage := fmt.Sprintf("%s (%s)", s.usage, envName(s.key))
+		switch value := s.field(&defaults).(type) {
+		case *string:
+			flags.String(s.flag, *value, usage)
+		case *time.Duration:
+			flags.Duration(s.flag, *value, usage)
+		case *bool:
+			flags.Bool(s.flag, *value, usage)
+		case *float64:
+			flags.Float64(s.flag, *value, usage)
+		}
+	}
+}
+
+// Load builds the effective configuration from the defaults, the config
+// file, the environment and the flags explicitly set in flags.
+func Load(flags *pflag.FlagSet, lookupEnv func(string) (string, bool)) (*Config, error) {
+	config := Default()
+
+	path, _ := lookupEnv(envName(ConfigFlag))
+	if flag := flags.Lookup(ConfigFlag); flag != nil && flag.Changed {
+		path = flag.Value.String()
+	}
+	if path != "" {
+		if err := loadFile(path, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := lookupEnv(envName(s.key)); ok {
+			if err := set(s.field(&config), value); err != nil {
+				return nil, fmt.Errorf("%s: %w", envName(s.key), err)
+			}
+		}
+		if flag := flags.Lookup(s.flag); flag != nil && flag.Changed {
+			if err := set(s.field(&config), flag.Value.String()); err != nil {
+				return nil, fmt.Errorf("--%s: %w", s.flag, err)
+			}
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func loadFile(path string, config *Config) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(raw))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(raw), config)
+		if err != nil {
+			return fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) != 0 {
+			return fmt.Errorf("parsing config file %s: unknown key %q", path, undecoded[0].String())
+		}
+	default:
+		return fmt.Errorf("config file %s must end in .yaml, .yml or .toml, got %q", path, ext)
+	}
+	return nil
+}
+
+func set(field any, value string) error {
+	switch field := field.(type) {
+	case *string:
+		*field = value
+	case *time.Duration:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field = duration
+	case *bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field = b
+	case *float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*field = f
+	default:
+		return fmt.Errorf("unsupported config field type %T", field)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+	if _, _, err := net.SplitHostPort(c.Server.Address); err != nil {
+		errs = append(errs, fmt.Errorf("server.address: %w", err))
+	}
+	if _, _, err := net.SplitHostPort(c.Server.HTTPAddress); err != nil {
+		errs = append(errs, fmt.Errorf("server.http_address: %w", err))
+	}
+	if c.Server.HealthCheckInterval <= 0 {
+		errs = append(errs, errors.New("server.health_check_interval: must be positive"))
+	}
+	if c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout: must not be negative"))
+	}
+	if tls := c.Server.TLS; tls.Enabled() {
+		if tls.CertFile == "" || tls.KeyFile == "" {
+			errs = append(errs, errors.New("server.tls: cert_file and key_file must be set together"))
+		}
+		if tls.ReloadInterval <= 0 {
+			errs = append(errs, errors.New("server.tls.reload_interval: must be positive"))
+		}
+	} else if c.Server.TLS.ClientCAFile != "" {
+		errs = append(errs, errors.New("server.tls.client_ca_file: requires cert_file and key_file"))
+	}
+	switch c.Database.Driver {
+	case database.DriverSQLite, database.DriverPostgres, database.DriverMySQL:
+	default:
+		errs = append(errs, fmt.Errorf("database.driver: must be %s, %s or %s, got %q",
+			database.DriverSQLite, database.DriverPostgres, database.DriverMySQL, c.Database.Driver))
+	}
+	if c.Database.DSN == "" {
+		errs = append(errs, errors.New("database.dsn: is required"))
+	}
+	if _, err := zapcore.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "console" {
+		errs = append(errs, fmt.Errorf("log.format: must be json or console, got %q", c.Log.Format))
+	}
+	if c.Retention.PurgeAfter < 0 {
+		errs = append(errs, errors.New("retention.purge_after: must not be negative"))
+	}
+	if c.Retention.PurgeAfter > 0 && c.Retention.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("retention.purge_interval: must be positive when purging is enabled"))
+	}
+	if c.Auth.HS256Secret != "" && len(c.Auth.HS256Secret) < auth.MinHS256SecretLength {
+		errs = append(errs, fmt.Errorf("auth.hs256_secret: must be at least %d bytes", auth.MinHS256SecretLength))
+	}
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: must be %s, %s or %s, got %q",
+			tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP, c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio: must be between 0 and 1, got %v", c.Tracing.SampleRatio))
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of the configuration that is safe to print.
+func (c Config) Redacted() Config {
+	c.Database.DSN = database.RedactDSN(c.Database.Driver, c.Database.DSN)
+	if c.Auth.HS256Secret != "" {
+		c.Auth.HS256Secret = "xxxxx"
+	}
+	return c
+}
+
+// NewLogger builds the logger described by the log settings.
+func (c LogConfig) NewLogger() (*zap.Logger, error) {
+	level, err := zap.ParseAtomicLevel(c.Level)
+	if err != nil {
+		return nil, err
+	}
+	config := zap.NewProductionConfig()
+	if c.Format == "console" {
+		config = zap.NewDevelopmentConfig()
+	}
+	config.Level = level
+	return config.Build()
+}
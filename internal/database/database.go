@@ -0,0 +1,152 @@
+// Package database opens the ent client for the configured SQL backend.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var keywordPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+const redactedPassword = "xxxxx"
+
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// Config selects the SQL backend posts are stored in.
+type Config struct {
+	// Driver is one of DriverSQLite, DriverPostgres or DriverMySQL.
+	Driver string
+	// DSN is passed to the driver after the settings the repository relies on
+	// have been filled in.
+	DSN string
+}
+
+// OpenDB connects to the configured database. The schema is not touched.
+func OpenDB(ctx context.Context, config Config) (*sql.DB, error) {
+	dsn, err := prepareDSN(config)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(config.Driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s database: %w", config.Driver, err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to %s database: %w", config.Driver, err)
+	}
+	return db, nil
+}
+
+// NewClient returns an ent client on top of a database opened with OpenDB.
+// The ent driver is passed through wrappers, e.g. to trace its statements.
+func NewClient(driver string, db *sql.DB, wrappers ...func(dialect.Driver) dialect.Driver) *ent.Client {
+	var drv dialect.Driver = entsql.OpenDB(Dialect(driver), db)
+	for _, wrap := range wrappers {
+		drv = wrap(drv)
+	}
+	return ent.NewClient(ent.Driver(drv))
+}
+
+// Dialect returns the ent dialect matching driver.
+func Dialect(driver string) string {
+	switch driver {
+	case DriverPostgres:
+		return dialect.Postgres
+	case DriverMySQL:
+		return dialect.MySQL
+	default:
+		return dialect.SQLite
+	}
+}
+
+func prepareDSN(config Config) (string, error) {
+	if config.DSN == "" {
+		return "", fmt.Errorf("database dsn is required")
+	}
+	switch config.Driver {
+	case DriverSQLite:
+		return sqliteDSN(config.DSN)
+	case DriverPostgres:
+		return config.DSN, nil
+	case DriverMySQL:
+		return mysqlDSN(config.DSN)
+	default:
+		return "", fmt.Errorf("unsupported database driver %q, want one of %s, %s or %s",
+			config.Driver, DriverSQLite, DriverPostgres, DriverMySQL)
+	}
+}
+
+// sqliteDSN enables foreign keys, which the revision cascade depends on, and
+// for file databases switches to WAL so readers are not blocked by a writer.
+// Transactions take the write lock up front because the repository reads
+// before it writes, and upgrading a deferred transaction fails immediately
+// with SQLITE_BUSY instead of waiting for the busy timeout.
+func sqliteDSN(dsn string) (string, error) {
+	path, rawQuery, _ := strings.Cut(dsn, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("parsing sqlite dsn: %w", err)
+	}
+	setDefault(query, "_fk", "1", "_foreign_keys")
+	inMemory := query.Get("mode") == "memory" || strings.Contains(path, ":memory:")
+	if !inMemory {
+		setDefault(query, "_journal_mode", "WAL", "_journal")
+		setDefault(query, "_busy_timeout", "5000", "_timeout")
+		setDefault(query, "_txlock", "immediate")
+	}
+	return path + "?" + query.Encode(), nil
+}
+
+// setDefault sets key unless it or one of its aliases is already present.
+func setDefault(query url.Values, key, value string, aliases ...string) {
+	for _, name := range append(aliases, key) {
+		if query.Has(name) {
+			return
+		}
+	}
+	query.Set(key, value)
+}
+
+// mysqlDSN makes the driver scan DATETIME columns into time.Time.
+func mysqlDSN(dsn string) (string, error) {
+	config, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("parsing mysql dsn: %w", err)
+	}
+	config.ParseTime = true
+	return config.FormatDSN(), nil
+}
+
+// RedactDSN masks the password in dsn so it can be logged or printed.
+func RedactDSN(driver, dsn string) string {
+	if driver == DriverMySQL {
+		config, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return redactedPassword
+		}
+		if config.Passwd != "" {
+			config.Passwd = redactedPassword
+		}
+		return config.FormatDSN()
+	}
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	// postgres also accepts key=value connection strings
+	return keywordPassword.ReplaceAllString(dsn, "${1}"+redactedPassword)
+}
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"context"
+	"entgo.io/ent/dialect/sql/schema"
+	"errors"
+	"fmt"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	entmigrate "github.com/sdoshi579/cloudbees/internal/repository/ent/migrate"
+	"path/filepath"
+)
+
+// ErrNoChanges is returned by Diff when the migrations already match the ent schema.
+var ErrNoChanges = errors.New("migrations are in sync with the ent schema")
+
+// SourceDir returns the directory holding the migrations of driver, relative
+// to the repository root.
+func SourceDir(driver string) string {
+	return filepath.Join("internal", "database", "migration", "migrations", driver)
+}
+
+// DefaultDevURL returns a scratch database Diff can use for driver, if one
+// can be created without a server.
+func DefaultDevURL(driver string) string {
+	if driver == database.DriverSQLite {
+		return "sqlite://dev?mode=memory&_fk=1"
+	}
+	return ""
+}
+
+// Diff writes a migration named name to dir with the changes needed to bring
+// the existing migrations in line with the ent schema. The migrations are
+// replayed on the empty database at devURL to compute the current state.
+func Diff(ctx context.Context, driver, dir, devURL, name string) error {
+	migrationDir, err := sqltool.NewGolangMigrateDir(dir)
+	if err != nil {
+		return err
+	}
+	err = entmigrate.NamedDiff(ctx, devURL, name,
+		schema.WithDir(migrationDir),
+		schema.WithDialect(database.Dialect(driver)),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+		schema.WithDropColumn(true),
+		schema.WithDropIndex(true),
+		schema.WithErrNoPlan(true),
+	)
+	switch {
+	case errors.Is(err, migrate.ErrNoPlan):
+		return ErrNoChanges
+	case err != nil:
+		return fmt.Errorf("computing %s migration: %w", driver, err)
+	}
+	return nil
+}
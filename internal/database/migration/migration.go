@@ -0,0 +1,338 @@
+// Package migration applies the versioned schema migrations generated from
+// the ent schema. Files use the golang-migrate layout, one directory per
+// driver, and are checked against the directory's atlas.sum before anything
+// runs. The applied version is tracked in a golang-migrate compatible
+// schema_migrations table.
+package migration
+
+import (
+	"ariga.io/atlas/sql/migrate"
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+const versionTable = "schema_migrations"
+
+var (
+	// ErrDirty is returned when an earlier migration failed halfway and the
+	// schema has to be repaired by hand.
+	ErrDirty = errors.New("database schema is dirty")
+	// ErrNotUpToDate is returned by Check when migrations are pending.
+	ErrNotUpToDate = errors.New("database schema is not up to date")
+)
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version uint64
+	Name    string
+	up      migrate.File
+	down    migrate.File
+}
+
+// Status describes how far the database is migrated.
+type Status struct {
+	// Current is the applied version, 0 if no migration ran yet.
+	Current uint64
+	Dirty   bool
+	Latest  uint64
+	Applied []Migration
+	Pending []Migration
+}
+
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	migrations []Migration
+}
+
+// New returns a migrator running the migrations bundled for driver against db.
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	dir, err := fs.Sub(migrations, "migrations/"+driver)
+	if err != nil {
+		return nil, err
+	}
+	return newMigrator(db, driver, dir)
+}
+
+func newMigrator(db *sql.DB, driver string, fsys fs.FS) (*Migrator, error) {
+	dir := &embeddedDir{FS: fsys}
+	if err := migrate.Validate(dir); err != nil {
+		return nil, fmt.Errorf("validating %s migrations: %w", driver, err)
+	}
+	files, err := dir.Files()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no migrations found for driver %q", driver)
+	}
+	list, err := pair(files)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s migrations: %w", driver, err)
+	}
+	return &Migrator{db: db, driver: driver, migrations: list}, nil
+}
+
+// pair matches every up file with its down file.
+func pair(files []migrate.File) ([]Migration, error) {
+	byVersion := make(map[uint64]*Migration)
+	for _, file := range files {
+		name := file.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("unexpected migration file %q", name)
+		}
+		rawVersion, description, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(rawVersion, 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration file %q does not start with a version", name)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: description}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.up = file
+		} else {
+			m.down = file
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == nil || m.down == nil {
+			return nil, fmt.Errorf("migration %d needs both an up and a down file", m.Version)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// Status reports the applied version and the migrations on either side of it.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	current, dirty, err := m.version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &Status{Current: current, Dirty: dirty, Latest: m.migrations[len(m.migrations)-1].Version}
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			status.Applied = append(status.Applied, migration)
+		} else {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	if current != 0 && m.index(current) < 0 {
+		return nil, fmt.Errorf("database is at version %d which is not a known migration", current)
+	}
+	return status, nil
+}
+
+// Check returns an error unless every migration has been applied cleanly.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	if len(status.Pending) != 0 {
+		return fmt.Errorf("%w: at version %d, want %d", ErrNotUpToDate, status.Current, status.Latest)
+	}
+	return nil
+}
+
+// Up applies up to steps pending migrations, all of them if steps is 0.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	pending := status.Pending
+	if steps > 0 && steps < len(pending) {
+		pending = pending[:steps]
+	}
+	for i, migration := range pending {
+		if err := m.apply(ctx, migration.up, migration.Version); err != nil {
+			return pending[:i], fmt.Errorf("applying migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down reverts up to steps applied migrations, newest first. Unlike Up it
+// reverts a single migration when steps is 0, since reverting everything
+// drops all data.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("%w at version %d", ErrDirty, status.Current)
+	}
+	if steps <= 0 {
+		steps = 1
+	}
+	var reverted []Migration
+	for i := len(status.Applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration := status.Applied[i]
+		var previous uint64
+		if i > 0 {
+			previous = status.Applied[i-1].Version
+		}
+		if err := m.apply(ctx, migration.down, previous); err != nil {
+			return reverted, fmt.Errorf("reverting migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		reverted = append(reverted, migration)
+	}
+	return reverted, nil
+}
+
+func (m *Migrator) index(version uint64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply runs the statements of file and records version as applied. SQLite
+// and Postgres run DDL transactionally, so a failure leaves the schema as it
+// was. MySQL commits every DDL statement implicitly, so the version is marked
+// dirty first and a failure leaves it that way.
+func (m *Migrator) apply(ctx context.Context, file migrate.File, version uint64) error {
+	stmts, err := file.Stmts()
+	if err != nil {
+		return err
+	}
+	if m.driver == database.DriverMySQL {
+		if err := setVersion(ctx, m.db, version, true); err != nil {
+			return err
+		}
+		for _, stmt := range stmts {
+			if _, err := m.db.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return setVersion(ctx, m.db, version, false)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := setVersion(ctx, tx, version, false); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// setVersion replaces the single row of the version table. A clean version 0
+// is stored as an empty table and a dirty one as golang-migrate's -1. The
+// values are formatted into the statement because placeholder syntax differs
+// between drivers.
+func setVersion(ctx context.Context, db execer, version uint64, dirty bool) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM "+versionTable); err != nil {
+		return fmt.Errorf("clearing schema version: %w", err)
+	}
+	if version == 0 && !dirty {
+		return nil
+	}
+	value := int64(version)
+	if version == 0 {
+		value = -1
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (version, dirty) VALUES (%d, %t)", versionTable, value, dirty))
+	if err != nil {
+		return fmt.Errorf("recording schema version: %w", err)
+	}
+	return nil
+}
+
+func (m *Migrator) version(ctx context.Context) (uint64, bool, error) {
+	_, err := m.db.ExecContext(ctx,
+		"CREATE TABLE IF NOT EXISTS "+versionTable+" (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)")
+	if err != nil {
+		return 0, false, fmt.Errorf("creating %s table: %w", versionTable, err)
+	}
+	var (
+		version int64
+		dirty   bool
+	)
+	err = m.db.QueryRowContext(ctx, "SELECT version, dirty FROM "+versionTable+" LIMIT 1").Scan(&version, &dirty)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("reading schema version: %w", err)
+	case version < 0:
+		return 0, dirty, nil
+	}
+	return uint64(version), dirty, nil
+}
+
+// embeddedDir is a read-only migrate.Dir over the bundled files. It lists and
+// hashes files the same way migrate.LocalDir does, so the atlas.sum written
+// by Diff verifies here.
+type embeddedDir struct {
+	fs.FS
+}
+
+func (d *embeddedDir) Files() ([]migrate.File, error) {
+	names, err := fs.Glob(d, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	files := make([]migrate.File, 0, len(names))
+	for _, name := range names {
+		b, err := fs.ReadFile(d, name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, migrate.NewLocalFile(name, b))
+	}
+	return files, nil
+}
+
+func (d *embeddedDir) Checksum() (migrate.HashFile, error) {
+	files, err := d.Files()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewHashFile(files)
+}
+
+func (d *embeddedDir) WriteFile(string, []byte) error {
+	return errors.New("bundled migrations are read-only")
+}
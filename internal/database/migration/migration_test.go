@@ -0,0 +1,155 @@
+package migration
+
+import (
+	"ariga.io/atlas/sql/migrate"
+	"context"
+	"database/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"errors"
+	"github.com/sdoshi579/cloudbees/internal/database"
+	"testing"
+	"testing/fstest"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := database.OpenDB(context.Background(), database.Config{
+		Driver: database.DriverSQLite,
+		DSN:    "file:" + t.Name() + "?mode=memory&cache=shared",
+	})
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// testDir returns a migration directory with a valid atlas.sum for files.
+func testDir(t *testing.T, files map[string]string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	dir := &embeddedDir{FS: fsys}
+	local, err := dir.Files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := migrate.NewHashFile(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := sum.MarshalText()
+	fsys[migrate.HashFileName] = &fstest.MapFile{Data: raw}
+	return fsys
+}
+
+func TestMigrator_bundledSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m, err := New(db, database.DriverSQLite)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if err := m.Check(ctx); !errors.Is(err, ErrNotUpToDate) {
+		t.Fatalf("Check() before migrating error = %v, want %v", err, ErrNotUpToDate)
+	}
+	applied, err := m.Up(ctx, 0)
+	if err != nil || len(applied) != len(m.migrations) {
+		t.Fatalf("Up() = %d migrations, error = %v", len(applied), err)
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Fatalf("Check() after migrating error = %v", err)
+	}
+
+	// the migrated schema has to match what the ent schema describes
+	client := database.NewClient(database.DriverSQLite, db)
+	err = client.Schema.NamedDiff(ctx, "drift",
+		schema.WithDir(migrate.OpenMemDir(t.Name())),
+		schema.WithMigrationMode(schema.ModeInspect),
+		schema.WithDropColumn(true),
+		schema.WithDropIndex(true),
+		schema.WithErrNoPlan(true),
+	)
+	if !errors.Is(err, migrate.ErrNoPlan) {
+		t.Errorf("migrations drifted from the ent schema, NamedDiff() error = %v", err)
+	}
+
+	reverted, err := m.Down(ctx, len(applied))
+	if err != nil || len(reverted) != len(applied) {
+		t.Fatalf("Down() = %d migrations, error = %v", len(reverted), err)
+	}
+	status, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Current != 0 || status.Dirty || len(status.Pending) != len(m.migrations) {
+		t.Errorf("Status() after reverting = %+v", status)
+	}
+}
+
+func TestMigrator_steps(t *testing.T) {
+	ctx := context.Background()
+	m, err := newMigrator(openTestDB(t), database.DriverSQLite, testDir(t, map[string]string{
+		"1_a.up.sql":   "CREATE TABLE a (id integer);",
+		"1_a.down.sql": "DROP TABLE a;",
+		"2_b.up.sql":   "CREATE TABLE b (id integer);",
+		"2_b.down.sql": "DROP TABLE b;",
+	}))
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+
+	if applied, err := m.Up(ctx, 1); err != nil || len(applied) != 1 || applied[0].Name != "a" {
+		t.Fatalf("Up(1) = %+v, error = %v", applied, err)
+	}
+	if err := m.Check(ctx); !errors.Is(err, ErrNotUpToDate) {
+		t.Errorf("Check() error = %v, want %v", err, ErrNotUpToDate)
+	}
+	if applied, err := m.Up(ctx, 0); err != nil || len(applied) != 1 || applied[0].Name != "b" {
+		t.Fatalf("Up(0) = %+v, error = %v", applied, err)
+	}
+	if reverted, err := m.Down(ctx, 0); err != nil || len(reverted) != 1 || reverted[0].Name != "b" {
+		t.Fatalf("Down(0) = %+v, error = %v", reverted, err)
+	}
+	status, err := m.Status(ctx)
+	if err != nil || status.Current != 1 {
+		t.Errorf("Status() = %+v, error = %v", status, err)
+	}
+}
+
+func TestMigrator_failedMigrationRollsBack(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m, err := newMigrator(db, database.DriverSQLite, testDir(t, map[string]string{
+		"1_broken.up.sql":   "CREATE TABLE a (id integer);\nCREATE TABLE a (id integer);",
+		"1_broken.down.sql": "DROP TABLE a;",
+	}))
+	if err != nil {
+		t.Fatalf("newMigrator() error = %v", err)
+	}
+
+	if _, err := m.Up(ctx, 0); err == nil {
+		t.Fatal("Up() error = nil, want the duplicate table to fail")
+	}
+	status, err := m.Status(ctx)
+	if err != nil || status.Current != 0 || status.Dirty {
+		t.Errorf("Status() = %+v, error = %v, want a clean version 0", status, err)
+	}
+	if _, err := db.Exec("SELECT id FROM a"); err == nil {
+		t.Error("table a survived the failed migration")
+	}
+}
+
+func TestNew_checksumMismatch(t *testing.T) {
+	fsys := testDir(t, map[string]string{
+		"1_a.up.sql":   "CREATE TABLE a (id integer);",
+		"1_a.down.sql": "DROP TABLE a;",
+	})
+	fsys["1_a.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE b (id integer);")}
+
+	_, err := newMigrator(openTestDB(t), database.DriverSQLite, fsys)
+	if !errors.Is(err, migrate.ErrChecksumMismatch) {
+		t.Errorf("newMigrator() error = %v, want %v", err, migrate.ErrChecksumMismatch)
+	}
+}
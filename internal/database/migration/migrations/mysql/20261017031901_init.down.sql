@@ -0,0 +1,4 @@
+-- reverse: create "post_revisions" table
+DROP TABLE `post_revisions`;
+-- reverse: create "posts" table
+DROP TABLE `posts`;
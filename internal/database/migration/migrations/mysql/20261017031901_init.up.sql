@@ -0,0 +1,4 @@
+-- create "posts" table
+CREATE TABLE `posts` (`id` char(36) NOT NULL, `title` varchar(255) NOT NULL, `content` longtext NOT NULL, `author` varchar(255) NOT NULL, `published_on` timestamp NOT NULL, `tags` json NOT NULL, `is_deleted` bool NOT NULL DEFAULT false, `deleted_at` timestamp NULL, `deleted_by` varchar(255) NULL, `revision` bigint NOT NULL DEFAULT 1, `changed_by` varchar(255) NOT NULL DEFAULT "", `created_at` timestamp NOT NULL, `updated_at` timestamp NOT NULL, PRIMARY KEY (`id`)) CHARSET utf8mb4 COLLATE utf8mb4_bin;
+-- create "post_revisions" table
+CREATE TABLE `post_revisions` (`id` char(36) NOT NULL, `revision` bigint NOT NULL, `title` varchar(255) NOT NULL, `content` longtext NOT NULL, `author` varchar(255) NOT NULL, `published_on` timestamp NOT NULL, `tags` json NOT NULL, `changed_by` varchar(255) NOT NULL DEFAULT "", `created_at` timestamp NOT NULL, `post_id` char(36) NOT NULL, PRIMARY KEY (`id`), UNIQUE INDEX `postrevision_post_id_revision` (`post_id`, `revision`), CONSTRAINT `post_revisions_posts_revisions` FOREIGN KEY (`post_id`) REFERENCES `posts` (`id`) ON DELETE CASCADE) CHARSET utf8mb4 COLLATE utf8mb4_bin;
@@ -0,0 +1,2 @@
+-- modify "posts" table
+ALTER TABLE `posts` ADD COLUMN `owner` varchar(255) NOT NULL DEFAULT "" AFTER `author`;
@@ -0,0 +1,2 @@
+-- create "api_keys" table
+CREATE TABLE `api_keys` (`id` char(36) NOT NULL, `name` varchar(255) NOT NULL, `key_hash` varchar(255) NOT NULL, `key_prefix` varchar(255) NOT NULL, `scopes` json NOT NULL, `created_by` varchar(255) NOT NULL DEFAULT "", `expires_at` timestamp NULL, `revoked_at` timestamp NULL, `created_at` timestamp NOT NULL, PRIMARY KEY (`id`), UNIQUE INDEX `api_keys_name_key` (`name`), UNIQUE INDEX `api_keys_key_hash_key` (`key_hash`)) CHARSET utf8mb4 COLLATE utf8mb4_bin;
@@ -0,0 +1,6 @@
+-- create "posts" table
+CREATE TABLE "posts" ("id" uuid NOT NULL, "title" character varying NOT NULL, "content" text NOT NULL, "author" character varying NOT NULL, "published_on" timestamptz NOT NULL, "tags" jsonb NOT NULL, "is_deleted" boolean NOT NULL DEFAULT false, "deleted_at" timestamptz NULL, "deleted_by" character varying NULL, "revision" bigint NOT NULL DEFAULT 1, "changed_by" character varying NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create "post_revisions" table
+CREATE TABLE "post_revisions" ("id" uuid NOT NULL, "revision" bigint NOT NULL, "title" character varying NOT NULL, "content" text NOT NULL, "author" character varying NOT NULL, "published_on" timestamptz NOT NULL, "tags" jsonb NOT NULL, "changed_by" character varying NOT NULL DEFAULT '', "created_at" timestamptz NOT NULL, "post_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "post_revisions_posts_revisions" FOREIGN KEY ("post_id") REFERENCES "posts" ("id") ON DELETE CASCADE);
+-- create index "postrevision_post_id_revision" to table: "post_revisions"
+CREATE UNIQUE INDEX "postrevision_post_id_revision" ON "post_revisions" ("post_id", "revision");
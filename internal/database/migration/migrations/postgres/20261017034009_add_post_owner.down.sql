@@ -0,0 +1,2 @@
+-- reverse: modify "posts" table
+ALTER TABLE "posts" DROP COLUMN "owner";
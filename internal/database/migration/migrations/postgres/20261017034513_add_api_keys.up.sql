@@ -0,0 +1,6 @@
+-- create "api_keys" table
+CREATE TABLE "api_keys" ("id" uuid NOT NULL, "name" character varying NOT NULL, "key_hash" character varying NOT NULL, "key_prefix" character varying NOT NULL, "scopes" jsonb NOT NULL, "created_by" character varying NOT NULL DEFAULT '', "expires_at" timestamptz NULL, "revoked_at" timestamptz NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "api_keys_name_key" to table: "api_keys"
+CREATE UNIQUE INDEX "api_keys_name_key" ON "api_keys" ("name");
+-- create index "api_keys_key_hash_key" to table: "api_keys"
+CREATE UNIQUE INDEX "api_keys_key_hash_key" ON "api_keys" ("key_hash");
@@ -0,0 +1,6 @@
+-- reverse: create index "postrevision_post_id_revision" to table: "post_revisions"
+DROP INDEX `postrevision_post_id_revision`;
+-- reverse: create "post_revisions" table
+DROP TABLE `post_revisions`;
+-- reverse: create "posts" table
+DROP TABLE `posts`;
@@ -0,0 +1,6 @@
+-- create "posts" table
+CREATE TABLE `posts` (`id` uuid NOT NULL, `title` text NOT NULL, `content` text NOT NULL, `author` text NOT NULL, `published_on` datetime NOT NULL, `tags` json NOT NULL, `is_deleted` bool NOT NULL DEFAULT (false), `deleted_at` datetime NULL, `deleted_by` text NULL, `revision` integer NOT NULL DEFAULT (1), `changed_by` text NOT NULL DEFAULT (''), `created_at` datetime NOT NULL, `updated_at` datetime NOT NULL, PRIMARY KEY (`id`));
+-- create "post_revisions" table
+CREATE TABLE `post_revisions` (`id` uuid NOT NULL, `revision` integer NOT NULL, `title` text NOT NULL, `content` text NOT NULL, `author` text NOT NULL, `published_on` datetime NOT NULL, `tags` json NOT NULL, `changed_by` text NOT NULL DEFAULT (''), `created_at` datetime NOT NULL, `post_id` uuid NOT NULL, PRIMARY KEY (`id`), CONSTRAINT `post_revisions_posts_revisions` FOREIGN KEY (`post_id`) REFERENCES `posts` (`id`) ON DELETE CASCADE);
+-- create index "postrevision_post_id_revision" to table: "post_revisions"
+CREATE UNIQUE INDEX `postrevision_post_id_revision` ON `post_revisions` (`post_id`, `revision`);
@@ -0,0 +1,2 @@
+-- reverse: add column "owner" to table: "posts"
+ALTER TABLE `posts` DROP COLUMN `owner`;
@@ -0,0 +1,2 @@
+-- add column "owner" to table: "posts"
+ALTER TABLE `posts` ADD COLUMN `owner` text NOT NULL DEFAULT ('');
@@ -0,0 +1,6 @@
+-- create "api_keys" table
+CREATE TABLE `api_keys` (`id` uuid NOT NULL, `name` text NOT NULL, `key_hash` text NOT NULL, `key_prefix` text NOT NULL, `scopes` json NOT NULL, `created_by` text NOT NULL DEFAULT (''), `expires_at` datetime NULL, `revoked_at` datetime NULL, `created_at` datetime NOT NULL, PRIMARY KEY (`id`));
+-- create index "api_keys_name_key" to table: "api_keys"
+CREATE UNIQUE INDEX `api_keys_name_key` ON `api_keys` (`name`);
+-- create index "api_keys_key_hash_key" to table: "api_keys"
+CREATE UNIQUE INDEX `api_keys_key_hash_key` ON `api_keys` (`key_hash`);
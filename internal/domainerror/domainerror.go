@@ -0,0 +1,136 @@
+// Package domainerror defines the errors the service and repository layers
+// report, so that transports can translate them without parsing messages.
+package domainerror
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindInvalidArgument
+	KindAlreadyExists
+	KindFailedPrecondition
+	KindConflict
+	KindPermissionDenied
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindInvalidArgument:
+		return "invalid argument"
+	case KindAlreadyExists:
+		return "already exists"
+	case KindFailedPrecondition:
+		return "failed precondition"
+	case KindConflict:
+		return "conflict"
+	case KindPermissionDenied:
+		return "permission denied"
+	default:
+		return "internal"
+	}
+}
+
+// FieldViolation describes why a single request field is invalid.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+type Error struct {
+	Kind    Kind
+	Message string
+	// Resource and ResourceID identify the entity the error is about, if any.
+	Resource   string
+	ResourceID string
+	Violations []FieldViolation
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap returns a copy of the error with the underlying cause attached, so
+// that errors declared once at package level can be wrapped per request.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+func NotFound(resource, id string) *Error {
+	return &Error{
+		Kind:       KindNotFound,
+		Message:    fmt.Sprintf("%s %s not found", resource, id),
+		Resource:   resource,
+		ResourceID: id,
+	}
+}
+
+func InvalidArgument(message string, violations ...FieldViolation) *Error {
+	return &Error{
+		Kind:       KindInvalidArgument,
+		Message:    message,
+		Violations: violations,
+	}
+}
+
+func AlreadyExists(resource, id string) *Error {
+	return &Error{
+		Kind:       KindAlreadyExists,
+		Message:    fmt.Sprintf("%s %s already exists", resource, id),
+		Resource:   resource,
+		ResourceID: id,
+	}
+}
+
+func FailedPrecondition(message string) *Error {
+	return &Error{
+		Kind:    KindFailedPrecondition,
+		Message: message,
+	}
+}
+
+// Conflict reports a concurrent modification of the given resource.
+func Conflict(resource, id, message string) *Error {
+	return &Error{
+		Kind:       KindConflict,
+		Message:    message,
+		Resource:   resource,
+		ResourceID: id,
+	}
+}
+
+// PermissionDenied reports a caller that is not allowed to perform the
+// operation.
+func PermissionDenied(message string) *Error {
+	return &Error{
+		Kind:    KindPermissionDenied,
+		Message: message,
+	}
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// KindInternal if there is none.
+func KindOf(err error) Kind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+	return KindInternal
+}
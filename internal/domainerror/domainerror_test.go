@@ -0,0 +1,22 @@
+package domainerror
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestError_Wrap(t *testing.T) {
+	shared := FailedPrecondition("post is not deleted")
+	cause := errors.New("cause")
+
+	wrapped := shared.Wrap(cause)
+	if !errors.Is(wrapped, cause) {
+		t.Errorf("Wrap() = %v, want it to wrap %v", wrapped, cause)
+	}
+	if wrapped.Kind != KindFailedPrecondition || wrapped.Message != shared.Message {
+		t.Errorf("Wrap() = %+v, want the kind and message of %+v", wrapped, shared)
+	}
+	if shared.Err != nil {
+		t.Errorf("Wrap() changed the wrapped error, its cause = %v", shared.Err)
+	}
+}
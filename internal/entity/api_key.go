@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateAPIKeyRequest struct {
+	Name string
+	// Scopes are the names of the roles granted to callers presenting the key.
+	Scopes    []string
+	ExpiresAt *time.Time
+	// CreatedBy identifies who minted the key, it is recorded when set.
+	CreatedBy string
+	// KeyHash and KeyPrefix are filled in by the service from the generated key.
+	KeyHash   string
+	KeyPrefix string
+}
+
+type APIKey struct {
+	ID        uuid.UUID
+	Name      string
+	KeyPrefix string
+	Scopes    []string
+	CreatedBy string
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// CreatedAPIKey is a newly minted key together with its secret, which is
+// not stored and cannot be retrieved later.
+type CreatedAPIKey struct {
+	APIKey
+	Key string
+}
@@ -11,13 +11,41 @@ type CreatePostRequest struct {
 	Author      string
 	PublishedOn time.Time
 	Tags        []string
+	// Owner is the subject of the principal creating the post, if any. It is
+	// also recorded as who made the first revision.
+	Owner string
 }
 
+// Paths accepted in UpdatePostRequest.UpdateMask.
+const (
+	PostFieldTitle       = "title"
+	PostFieldContent     = "content"
+	PostFieldAuthor      = "author"
+	PostFieldPublishedOn = "published_on"
+	PostFieldTags        = "tags"
+)
+
 type UpdatePostRequest struct {
-	Title   *string
-	Content *string
-	Author  *string
-	Tags    []string
+	Title       *string
+	Content     *string
+	Author      *string
+	PublishedOn *time.Time
+	Tags        []string
+	// UpdateMask lists the fields to write, fields outside of it are left untouched.
+	UpdateMask []string
+	// ExpectedRevision guards against lost updates when non-zero.
+	ExpectedRevision int64
+	// ChangedBy identifies who made the change, it is recorded on the revision.
+	ChangedBy string
+}
+
+type DeletePostRequest struct {
+	ExpectedRevision int64
+	// DeletedBy identifies who deleted the post, it is recorded when set and
+	// on the revision of the deletion.
+	DeletedBy string
+	// AllowMissing makes deleting a post that does not exist succeed.
+	AllowMissing bool
 }
 
 type PostDetail struct {
@@ -27,4 +55,55 @@ type PostDetail struct {
 	Author      string
 	PublishedOn time.Time
 	Tags        []string
+	CreatedAt   time.Time
+	Revision    int64
+	Owner       string
+}
+
+// PostCounts is the number of posts by state.
+type PostCounts struct {
+	Live    int
+	Deleted int
+}
+
+type TagMatch int
+
+const (
+	TagMatchAny TagMatch = iota
+	TagMatchAll
+)
+
+type PostSortField int
+
+const (
+	PostSortByCreatedAt PostSortField = iota
+	PostSortByPublishedOn
+	PostSortByTitle
+)
+
+// PostFilter narrows down a post listing, zero values mean no filtering.
+// PublishedAfter is inclusive and PublishedBefore is exclusive.
+type PostFilter struct {
+	Author          string
+	Tags            []string
+	TagMatch        TagMatch
+	PublishedAfter  *time.Time
+	PublishedBefore *time.Time
+}
+
+type PostSort struct {
+	Field      PostSortField
+	Descending bool
+}
+
+type ListPostsRequest struct {
+	PageSize  int
+	PageToken string
+	Filter    PostFilter
+	Sort      PostSort
+}
+
+type ListPostsResponse struct {
+	Posts         []*PostDetail
+	NextPageToken string
 }
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PostRevision struct {
+	PostID      uuid.UUID
+	Revision    int64
+	Title       string
+	Content     string
+	Author      string
+	PublishedOn time.Time
+	Tags        []string
+	// ChangedBy identifies who made the revision, empty when unknown.
+	ChangedBy string
+	CreatedAt time.Time
+}
+
+type ListRevisionsRequest struct {
+	PageSize  int
+	PageToken string
+}
+
+type ListRevisionsResponse struct {
+	// Revisions are newest first.
+	Revisions     []*PostRevision
+	NextPageToken string
+}
+
+type DiffOperation int
+
+const (
+	DiffEqual DiffOperation = iota
+	DiffInsert
+	DiffDelete
+)
+
+type DiffLine struct {
+	Operation DiffOperation
+	Text      string
+}
+
+// RevisionDiff describes the changes between two revisions of a post.
+// ChangedFields uses the UpdatePostRequest mask paths.
+type RevisionDiff struct {
+	FromRevision  int64
+	ToRevision    int64
+	ChangedFields []string
+	ContentDiff   []DiffLine
+}
+
+type RestoreRevisionRequest struct {
+	Revision         int64
+	ExpectedRevision int64
+}
@@ -0,0 +1,164 @@
+// Package health reports whether the server can take traffic, over the
+// standard grpc.health.v1 service and over plain HTTP probes.
+package health
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInterval = 10 * time.Second
+	checkTimeout    = 5 * time.Second
+)
+
+// Check returns an error while a dependency is not usable.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks periodically and publishes the result
+// for the overall server and every registered service.
+type Checker struct {
+	server   *grpchealth.Server
+	services []string
+	checks   []namedCheck
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu sync.Mutex
+	// notReady is the reason the server is not ready, empty once it is.
+	notReady     string
+	shuttingDown bool
+}
+
+type CheckerConfiguration func(c *Checker)
+
+// NewChecker returns a checker reporting NOT_SERVING for the overall server
+// and services until the first round of checks passes.
+func NewChecker(services []string, configs ...CheckerConfiguration) *Checker {
+	c := Checker{
+		server:   grpchealth.NewServer(),
+		services: append([]string{""}, services...),
+		interval: defaultInterval,
+		logger:   zap.NewNop(),
+		notReady: "not checked yet",
+	}
+	for _, config := range configs {
+		config(&c)
+	}
+	c.publish(healthpb.HealthCheckResponse_NOT_SERVING)
+	return &c
+}
+
+func WithLogger(logger *zap.Logger) CheckerConfiguration {
+	return func(c *Checker) {
+		c.logger = logger
+	}
+}
+
+// WithCheck adds a readiness check. Checks run in the order they were added.
+func WithCheck(name string, check Check) CheckerConfiguration {
+	return func(c *Checker) {
+		c.checks = append(c.checks, namedCheck{name: name, check: check})
+	}
+}
+
+func WithInterval(interval time.Duration) CheckerConfiguration {
+	return func(c *Checker) {
+		c.interval = interval
+	}
+}
+
+// Server returns the grpc.health.v1 implementation to register.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run checks right away and then on every interval until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs every check once and publishes the result.
+func (c *Checker) CheckNow(ctx context.Context) {
+	reason := ""
+	for _, check := range c.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := check.check(checkCtx)
+		cancel()
+		if err != nil {
+			reason = fmt.Sprintf("%s: %v", check.name, err)
+			break
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shuttingDown || reason == c.notReady {
+		return
+	}
+	if reason != "" {
+		c.logger.Warn("server is not ready", zap.String("reason", reason))
+		c.publish(healthpb.HealthCheckResponse_NOT_SERVING)
+	} else {
+		c.logger.Info("server is ready")
+		c.publish(healthpb.HealthCheckResponse_SERVING)
+	}
+	c.notReady = reason
+}
+
+// Shutdown reports NOT_SERVING from now on, so load balancers stop routing
+// new requests while in-flight ones drain.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shuttingDown = true
+	c.notReady = "shutting down"
+	c.server.Shutdown()
+}
+
+func (c *Checker) publish(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// Handler serves /healthz, which succeeds while the process is up, and
+// /readyz, which succeeds only while the server is ready for traffic.
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		reason := c.notReady
+		c.mu.Unlock()
+		if reason != "" {
+			http.Error(w, "not ready: "+reason, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
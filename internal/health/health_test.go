@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"errors"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testService = "post.v1.PostService"
+
+func assertStatus(t *testing.T, c *Checker, want healthpb.HealthCheckResponse_ServingStatus, wantReadyz int) {
+	t.Helper()
+	for _, service := range []string{"", testService} {
+		resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		if resp.Status != want {
+			t.Errorf("Check(%q) status = %v, want %v", service, resp.Status, want)
+		}
+	}
+
+	for path, wantCode := range map[string]int{"/healthz": http.StatusOK, "/readyz": wantReadyz} {
+		recorder := httptest.NewRecorder()
+		c.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != wantCode {
+			t.Errorf("GET %s = %d, want %d", path, recorder.Code, wantCode)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	ctx := context.Background()
+	var dbErr error
+	c := NewChecker([]string{testService},
+		WithCheck("migrations", func(ctx context.Context) error { return nil }),
+		WithCheck("database", func(ctx context.Context) error { return dbErr }),
+	)
+
+	// nothing is served before the first round of checks
+	assertStatus(t, c, healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+
+	c.CheckNow(ctx)
+	assertStatus(t, c, healthpb.HealthCheckResponse_SERVING, http.StatusOK)
+
+	dbErr = errors.New("connection refused")
+	c.CheckNow(ctx)
+	assertStatus(t, c, healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+
+	dbErr = nil
+	c.CheckNow(ctx)
+	assertStatus(t, c, healthpb.HealthCheckResponse_SERVING, http.StatusOK)
+
+	// once shutting down, passing checks must not bring the server back
+	c.Shutdown()
+	c.CheckNow(ctx)
+	assertStatus(t, c, healthpb.HealthCheckResponse_NOT_SERVING, http.StatusServiceUnavailable)
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"time"
+)
+
+// RequestIDHeader is the metadata key, and HTTP header, of the request ID.
+const RequestIDHeader = "x-request-id"
+
+const maxRequestIDLength = 128
+
+// Interceptor logs every call once it finished. The request ID sent by the
+// caller, or a new one, is returned in the response headers and added to
+// the logger that the handler finds in its context.
+type Interceptor struct {
+	logger *zap.Logger
+}
+
+func NewInterceptor(logger *zap.Logger) *Interceptor {
+	return &Interceptor{logger: logger}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, logger, requestID := i.start(ctx, info.FullMethod)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+		res, err := handler(ctx, req)
+		finish(ctx, logger, start, req, err)
+		return res, err
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, logger, requestID := i.start(stream.Context(), info.FullMethod)
+		_ = stream.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+		err := handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+		finish(ctx, logger, start, nil, err)
+		return err
+	}
+}
+
+func (i *Interceptor) start(ctx context.Context, fullMethod string) (context.Context, *zap.Logger, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := requestID(md)
+	fields := []zap.Field{zap.String("requestID", requestID), zap.String("method", fullMethod)}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		fields = append(fields, zap.Stringer("traceID", spanContext.TraceID()))
+	}
+	logger := i.logger.With(fields...)
+	return NewContext(ctx, logger), logger, requestID
+}
+
+// finish logs the outcome of a call. Codes that point at a server problem
+// are logged as errors, the others, e.g. NOT_FOUND, as info. The request of a
+// failed unary call is logged along, redacted by Proto.
+func finish(ctx context.Context, logger *zap.Logger, start time.Time, req any, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{zap.Duration("duration", time.Since(start)), zap.Stringer("code", code)}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.Stringer("peer", p.Addr))
+	}
+	// the REST gateway calls from loopback and passes the client on
+	md, _ := metadata.FromIncomingContext(ctx)
+	if forwardedFor := md.Get("x-forwarded-for"); len(forwardedFor) != 0 {
+		fields = append(fields, zap.Strings("forwardedFor", forwardedFor))
+	}
+	level := zapcore.InfoLevel
+	switch code {
+	case codes.OK:
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		level = zapcore.ErrorLevel
+		fields = append(fields, zap.Error(err))
+	default:
+		fields = append(fields, zap.Error(err))
+	}
+	if msg, ok := req.(proto.Message); ok && err != nil {
+		fields = append(fields, Proto("request", msg))
+	}
+	logger.Log(level, "finished call", fields...)
+}
+
+// requestID returns the request ID sent by the caller, or a new one when
+// there is none or it is not a short printable ASCII string.
+func requestID(md metadata.MD) string {
+	if values := md.Get(RequestIDHeader); len(values) != 0 && validRequestID(values[0]) {
+		return values[0]
+	}
+	return uuid.NewString()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,25 @@
+// Package logging gives every call a request ID and a logger carrying it,
+// which the layers below the RPC handlers take from the context, and keeps
+// post content and other large fields out of the logs.
+package logging
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the call ctx belongs to, or fallback
+// outside of calls, e.g. in the retention job.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"context"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// transportStream records the headers set by the interceptor.
+type transportStream struct {
+	header metadata.MD
+}
+
+func (s *transportStream) Method() string {
+	return "/post.v1.PostService/Get"
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		err           error
+		wantRequestID string
+		wantLevel     zapcore.Level
+	}{
+		{name: "keeps the request id of the caller", md: metadata.Pairs(RequestIDHeader, "req-1"),
+			wantRequestID: "req-1", wantLevel: zapcore.InfoLevel},
+		{name: "assigns a request id", wantLevel: zapcore.InfoLevel},
+		{name: "replaces a malformed request id", md: metadata.Pairs(RequestIDHeader, "two words"),
+			wantLevel: zapcore.InfoLevel},
+		{name: "replaces a long request id", md: metadata.Pairs(RequestIDHeader, strings.Repeat("a", 129)),
+			wantLevel: zapcore.InfoLevel},
+		{name: "client errors are info", err: status.Error(codes.NotFound, "post not found"),
+			wantLevel: zapcore.InfoLevel},
+		{name: "server errors are errors", err: status.Error(codes.Internal, "database is locked"),
+			wantLevel: zapcore.ErrorLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			stream := &transportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(
+				metadata.NewIncomingContext(context.Background(), tt.md), stream)
+			interceptor := NewInterceptor(zap.New(core)).Unary()
+			request := &postv1.GetRequest{Id: "post-1"}
+			interceptor(ctx, request, &grpc.UnaryServerInfo{FullMethod: "/post.v1.PostService/Get"},
+				func(ctx context.Context, req any) (any, error) {
+					FromContext(ctx, zap.NewNop()).Info("in handler")
+					return nil, tt.err
+				})
+
+			entries := logs.AllUntimed()
+			if len(entries) != 2 {
+				t.Fatalf("logged %d entries, want the handler's and one for the call", len(entries))
+			}
+			requestID := entries[0].ContextMap()["requestID"]
+			if tt.wantRequestID != "" && requestID != tt.wantRequestID {
+				t.Errorf("requestID = %v, want %s", requestID, tt.wantRequestID)
+			}
+			if id, _ := requestID.(string); !validRequestID(id) {
+				t.Errorf("requestID = %q, want a valid request id", id)
+			}
+			if got := stream.header.Get(RequestIDHeader); len(got) != 1 || got[0] != requestID {
+				t.Errorf("%s header = %v, want %v", RequestIDHeader, got, requestID)
+			}
+
+			finished := entries[1]
+			fields := finished.ContextMap()
+			if finished.Message != "finished call" || finished.Level != tt.wantLevel {
+				t.Errorf("logged %s %q, want %s finished call", finished.Level, finished.Message, tt.wantLevel)
+			}
+			if fields["requestID"] != requestID || fields["method"] != "/post.v1.PostService/Get" {
+				t.Errorf("call logged with %v, want the request id and method", fields)
+			}
+			if fields["code"] != status.Code(tt.err).String() {
+				t.Errorf("code = %v, want %s", fields["code"], status.Code(tt.err))
+			}
+			if _, ok := fields["duration"]; !ok {
+				t.Error("duration is not logged")
+			}
+			if _, ok := fields["request"]; ok != (tt.err != nil) {
+				t.Errorf("request logged = %v, want it logged for failed calls only", ok)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	fallback := zap.NewNop()
+	if got := FromContext(context.Background(), fallback); got != fallback {
+		t.Error("FromContext() outside of a call did not return the fallback")
+	}
+	logger := zap.NewExample()
+	if got := FromContext(NewContext(context.Background(), logger), fallback); got != logger {
+		t.Error("FromContext() did not return the logger of the context")
+	}
+}
+
+func TestProto(t *testing.T) {
+	publishedOn := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		msg  *postv1.CreateRequest
+		want map[string]any
+	}{
+		{
+			name: "redacts content",
+			msg: &postv1.CreateRequest{Title: "title", Content: "secret content", Author: "author",
+				PublishedOn: timestamppb.New(publishedOn), Tags: []string{"go"}},
+			want: map[string]any{"title": "title", "content": "[redacted 14 bytes]", "author": "author",
+				"publishedOn": "2024-05-01T00:00:00Z", "tags": []any{"go"}},
+		},
+		{
+			name: "truncates long strings",
+			msg:  &postv1.CreateRequest{Title: strings.Repeat("é", 40)},
+			want: map[string]any{"title": strings.Repeat("é", 32) + "... [80 bytes]"},
+		},
+		{
+			name: "skips unset fields",
+			msg:  &postv1.CreateRequest{},
+			want: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder := zapcore.NewMapObjectEncoder()
+			Proto("request", tt.msg).AddTo(encoder)
+			if got := encoder.Fields["request"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Proto() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	list := &postv1.ListRequest{SortBy: postv1.SortField_SORT_FIELD_TITLE,
+		Filter: &postv1.PostFilter{Tags: []string{"go"}}}
+	encoder := zapcore.NewMapObjectEncoder()
+	Proto("request", list).AddTo(encoder)
+	want := map[string]any{"sortBy": "SORT_FIELD_TITLE", "filter": map[string]any{"tags": []any{"go"}}}
+	if got := encoder.Fields["request"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Proto() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"fmt"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+	"unicode/utf8"
+)
+
+// maxStringLength is the number of bytes logged of a string field.
+const maxStringLength = 64
+
+// redactedFields are logged by their length only, whichever message they
+// are in.
+var redactedFields = map[protoreflect.Name]bool{
+	"content": true,
+}
+
+// Proto logs msg under key like its JSON encoding, except that the fields in
+// redactedFields and bytes fields are replaced by their length and other
+// strings are truncated to maxStringLength bytes.
+func Proto(key string, msg proto.Message) zap.Field {
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return zap.Skip()
+	}
+	return zap.Any(key, redactMessage(msg.ProtoReflect()))
+}
+
+// String logs value under key truncated to maxStringLength bytes.
+func String(key, value string) zap.Field {
+	return zap.String(key, truncate(value))
+}
+
+func redactMessage(m protoreflect.Message) any {
+	if ts, ok := m.Interface().(*timestamppb.Timestamp); ok {
+		return ts.AsTime().Format(time.RFC3339Nano)
+	}
+	fields := map[string]any{}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			values := make([]any, list.Len())
+			for i := range values {
+				values[i] = redactValue(fd, list.Get(i))
+			}
+			fields[fd.JSONName()] = values
+		case fd.IsMap():
+			entries := map[string]any{}
+			v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				entries[key.String()] = redactValue(fd.MapValue(), value)
+				return true
+			})
+			fields[fd.JSONName()] = entries
+		default:
+			fields[fd.JSONName()] = redactValue(fd, v)
+		}
+		return true
+	})
+	return fields
+}
+
+func redactValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return redactMessage(v.Message())
+	case protoreflect.StringKind:
+		if redactedFields[fd.Name()] {
+			return fmt.Sprintf("[redacted %d bytes]", len(v.String()))
+		}
+		return truncate(v.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("[%d bytes]", len(v.Bytes()))
+	case protoreflect.EnumKind:
+		if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+			return string(value.Name())
+		}
+		return int32(v.Enum())
+	default:
+		return v.Interface()
+	}
+}
+
+// truncate cuts s to maxStringLength bytes without splitting a character.
+func truncate(s string) string {
+	if len(s) <= maxStringLength {
+		return s
+	}
+	cut := maxStringLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [%d bytes]", s[:cut], len(s))
+}
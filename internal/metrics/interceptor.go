@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+// Interceptor records the duration of every call, by service, method and
+// status code, in the cloudbees_rpc_duration_seconds histogram.
+type Interceptor struct {
+	duration *prometheus.HistogramVec
+}
+
+func NewInterceptor(registerer prometheus.Registerer) *Interceptor {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "duration_seconds",
+		Help:      "Duration of RPCs by service, method and gRPC status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "method", "code"})
+	registerer.MustRegister(duration)
+	return &Interceptor{duration: duration}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		res, err := handler(ctx, req)
+		i.observe(info.FullMethod, start, err)
+		return res, err
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		i.observe(info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (i *Interceptor) observe(fullMethod string, start time.Time, err error) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	i.duration.WithLabelValues(service, method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInterceptor_Unary(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	interceptor := NewInterceptor(registry).Unary()
+	call := func(method string, err error) {
+		interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req any) (any, error) {
+				return nil, err
+			})
+	}
+	call("/post.v1.PostService/Get", nil)
+	call("/post.v1.PostService/Get", nil)
+	call("/post.v1.PostService/Get", status.Error(codes.NotFound, "post not found"))
+	call("/post.v1.PostService/Create", errors.New("boom"))
+
+	want := `
+# HELP cloudbees_rpc_duration_seconds Duration of RPCs by service, method and gRPC status code.
+# TYPE cloudbees_rpc_duration_seconds histogram
+cloudbees_rpc_duration_seconds_count{code="NotFound",method="Get",service="post.v1.PostService"} 1
+cloudbees_rpc_duration_seconds_count{code="OK",method="Get",service="post.v1.PostService"} 2
+cloudbees_rpc_duration_seconds_count{code="Unknown",method="Create",service="post.v1.PostService"} 1
+`
+	if err := testutil.GatherAndCompare(countsOnly{registry}, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
+
+// countsOnly drops the buckets and sums of histograms, which depend on timing.
+type countsOnly struct {
+	prometheus.Gatherer
+}
+
+func (g countsOnly) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			if histogram := metric.GetHistogram(); histogram != nil {
+				metric.Histogram = &dto.Histogram{SampleCount: histogram.SampleCount}
+			}
+		}
+	}
+	return families, err
+}
+
+// postCounterFunc counts posts with a function.
+type postCounterFunc func(ctx context.Context) (*entity.PostCounts, error)
+
+func (f postCounterFunc) CountPosts(ctx context.Context) (*entity.PostCounts, error) {
+	return f(ctx)
+}
+
+func TestPostCollector(t *testing.T) {
+	counts := postCounterFunc(func(ctx context.Context) (*entity.PostCounts, error) {
+		return &entity.PostCounts{Live: 3, Deleted: 1}, nil
+	})
+	want := `
+# HELP cloudbees_posts Number of posts by state, live or deleted (soft-deleted and not purged yet).
+# TYPE cloudbees_posts gauge
+cloudbees_posts{state="deleted"} 1
+cloudbees_posts{state="live"} 3
+`
+	if err := testutil.CollectAndCompare(NewPostCollector(counts, zap.NewNop()), strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	failing := postCounterFunc(func(ctx context.Context) (*entity.PostCounts, error) {
+		return nil, errors.New("database is locked")
+	})
+	if count := testutil.CollectAndCount(NewPostCollector(failing, zap.NewNop())); count != 0 {
+		t.Errorf("collected %d metrics after a failed count, want none", count)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	recorder := httptest.NewRecorder()
+	Handler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	for _, name := range []string{"go_goroutines", "process_cpu_seconds_total"} {
+		if !strings.Contains(recorder.Body.String(), name) {
+			t.Errorf("GET /metrics is missing %s", name)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"go.uber.org/zap"
+	"time"
+)
+
+// countTimeout bounds the query counting the posts on every scrape.
+const countTimeout = 5 * time.Second
+
+// PostCounter counts the posts by state, it is implemented by the post
+// repository.
+type PostCounter interface {
+	CountPosts(ctx context.Context) (*entity.PostCounts, error)
+}
+
+// postCollector reports the number of posts as the cloudbees_posts gauge,
+// counted when Prometheus scrapes the metrics.
+type postCollector struct {
+	counter PostCounter
+	desc    *prometheus.Desc
+	logger  *zap.Logger
+}
+
+// NewPostCollector returns a collector of the number of live and
+// soft-deleted posts. A failed count is logged and leaves the gauge out of
+// the scrape rather than failing it.
+func NewPostCollector(counter PostCounter, logger *zap.Logger) prometheus.Collector {
+	return &postCollector{
+		counter: counter,
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "posts"),
+			"Number of posts by state, live or deleted (soft-deleted and not purged yet).", []string{"state"}, nil),
+		logger: logger,
+	}
+}
+
+func (c *postCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *postCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), countTimeout)
+	defer cancel()
+	counts, err := c.counter.CountPosts(ctx)
+	if err != nil {
+		c.logger.Error("error in counting posts for metrics", zap.Error(err))
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts.Live), "live")
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts.Deleted), "deleted")
+}
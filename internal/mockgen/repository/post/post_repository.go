@@ -7,6 +7,7 @@ package mock_post
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,21 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountPosts mocks base method.
+func (m *MockRepository) CountPosts(ctx context.Context) (*entity.PostCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPosts", ctx)
+	ret0, _ := ret[0].(*entity.PostCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPosts indicates an expected call of CountPosts.
+func (mr *MockRepositoryMockRecorder) CountPosts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPosts", reflect.TypeOf((*MockRepository)(nil).CountPosts), ctx)
+}
+
 // CreatePost mocks base method.
 func (m *MockRepository) CreatePost(ctx context.Context, request entity.CreatePostRequest) (*entity.PostDetail, error) {
 	m.ctrl.T.Helper()
@@ -52,18 +68,17 @@ func (mr *MockRepositoryMockRecorder) CreatePost(ctx, request interface{}) *gomo
 }
 
 // DeletePost mocks base method.
-func (m *MockRepository) DeletePost(ctx context.Context, id uuid.UUID) (bool, error) {
+func (m *MockRepository) DeletePost(ctx context.Context, id uuid.UUID, request entity.DeletePostRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePost", ctx, id)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeletePost", ctx, id, request)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // DeletePost indicates an expected call of DeletePost.
-func (mr *MockRepositoryMockRecorder) DeletePost(ctx, id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) DeletePost(ctx, id, request interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockRepository)(nil).DeletePost), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockRepository)(nil).DeletePost), ctx, id, request)
 }
 
 // GetPost mocks base method.
@@ -81,6 +96,110 @@ func (mr *MockRepositoryMockRecorder) GetPost(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockRepository)(nil).GetPost), ctx, id)
 }
 
+// GetPostOwner mocks base method.
+func (m *MockRepository) GetPostOwner(ctx context.Context, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostOwner", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostOwner indicates an expected call of GetPostOwner.
+func (mr *MockRepositoryMockRecorder) GetPostOwner(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostOwner", reflect.TypeOf((*MockRepository)(nil).GetPostOwner), ctx, id)
+}
+
+// GetRevision mocks base method.
+func (m *MockRepository) GetRevision(ctx context.Context, postID uuid.UUID, revision int64) (*entity.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, postID, revision)
+	ret0, _ := ret[0].(*entity.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRepositoryMockRecorder) GetRevision(ctx, postID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRepository)(nil).GetRevision), ctx, postID, revision)
+}
+
+// ListPosts mocks base method.
+func (m *MockRepository) ListPosts(ctx context.Context, request entity.ListPostsRequest) (*entity.ListPostsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPosts", ctx, request)
+	ret0, _ := ret[0].(*entity.ListPostsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockRepositoryMockRecorder) ListPosts(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockRepository)(nil).ListPosts), ctx, request)
+}
+
+// ListRevisions mocks base method.
+func (m *MockRepository) ListRevisions(ctx context.Context, postID uuid.UUID, request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, postID, request)
+	ret0, _ := ret[0].(*entity.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockRepositoryMockRecorder) ListRevisions(ctx, postID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockRepository)(nil).ListRevisions), ctx, postID, request)
+}
+
+// PurgeDeletedPosts mocks base method.
+func (m *MockRepository) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedPosts", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedPosts indicates an expected call of PurgeDeletedPosts.
+func (mr *MockRepositoryMockRecorder) PurgeDeletedPosts(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedPosts", reflect.TypeOf((*MockRepository)(nil).PurgeDeletedPosts), ctx, deletedBefore)
+}
+
+// PurgePost mocks base method.
+func (m *MockRepository) PurgePost(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePost", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgePost indicates an expected call of PurgePost.
+func (mr *MockRepositoryMockRecorder) PurgePost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePost", reflect.TypeOf((*MockRepository)(nil).PurgePost), ctx, id)
+}
+
+// RestorePost mocks base method.
+func (m *MockRepository) RestorePost(ctx context.Context, id uuid.UUID, restoredBy string) (*entity.PostDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePost", ctx, id, restoredBy)
+	ret0, _ := ret[0].(*entity.PostDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePost indicates an expected call of RestorePost.
+func (mr *MockRepositoryMockRecorder) RestorePost(ctx, id, restoredBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePost", reflect.TypeOf((*MockRepository)(nil).RestorePost), ctx, id, restoredBy)
+}
+
 // UpdatePost mocks base method.
 func (m *MockRepository) UpdatePost(ctx context.Context, id uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error) {
 	m.ctrl.T.Helper()
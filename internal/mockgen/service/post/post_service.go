@@ -7,6 +7,7 @@ package mock_post
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -52,18 +53,32 @@ func (mr *MockServiceMockRecorder) CreatePost(ctx, request interface{}) *gomock.
 }
 
 // DeletePost mocks base method.
-func (m *MockService) DeletePost(ctx context.Context, id uuid.UUID) (bool, error) {
+func (m *MockService) DeletePost(ctx context.Context, id uuid.UUID, request entity.DeletePostRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePost", ctx, id)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "DeletePost", ctx, id, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePost indicates an expected call of DeletePost.
+func (mr *MockServiceMockRecorder) DeletePost(ctx, id, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockService)(nil).DeletePost), ctx, id, request)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockService) DiffRevisions(ctx context.Context, postID uuid.UUID, fromRevision, toRevision int64) (*entity.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, postID, fromRevision, toRevision)
+	ret0, _ := ret[0].(*entity.RevisionDiff)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeletePost indicates an expected call of DeletePost.
-func (mr *MockServiceMockRecorder) DeletePost(ctx, id interface{}) *gomock.Call {
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockServiceMockRecorder) DiffRevisions(ctx, postID, fromRevision, toRevision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePost", reflect.TypeOf((*MockService)(nil).DeletePost), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockService)(nil).DiffRevisions), ctx, postID, fromRevision, toRevision)
 }
 
 // GetPost mocks base method.
@@ -81,6 +96,110 @@ func (mr *MockServiceMockRecorder) GetPost(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPost", reflect.TypeOf((*MockService)(nil).GetPost), ctx, id)
 }
 
+// GetRevision mocks base method.
+func (m *MockService) GetRevision(ctx context.Context, postID uuid.UUID, revision int64) (*entity.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, postID, revision)
+	ret0, _ := ret[0].(*entity.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockServiceMockRecorder) GetRevision(ctx, postID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockService)(nil).GetRevision), ctx, postID, revision)
+}
+
+// ListPosts mocks base method.
+func (m *MockService) ListPosts(ctx context.Context, request entity.ListPostsRequest) (*entity.ListPostsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPosts", ctx, request)
+	ret0, _ := ret[0].(*entity.ListPostsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPosts indicates an expected call of ListPosts.
+func (mr *MockServiceMockRecorder) ListPosts(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPosts", reflect.TypeOf((*MockService)(nil).ListPosts), ctx, request)
+}
+
+// ListRevisions mocks base method.
+func (m *MockService) ListRevisions(ctx context.Context, postID uuid.UUID, request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, postID, request)
+	ret0, _ := ret[0].(*entity.ListRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockServiceMockRecorder) ListRevisions(ctx, postID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockService)(nil).ListRevisions), ctx, postID, request)
+}
+
+// PurgeDeletedPosts mocks base method.
+func (m *MockService) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedPosts", ctx, deletedBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedPosts indicates an expected call of PurgeDeletedPosts.
+func (mr *MockServiceMockRecorder) PurgeDeletedPosts(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedPosts", reflect.TypeOf((*MockService)(nil).PurgeDeletedPosts), ctx, deletedBefore)
+}
+
+// PurgePost mocks base method.
+func (m *MockService) PurgePost(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePost", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgePost indicates an expected call of PurgePost.
+func (mr *MockServiceMockRecorder) PurgePost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePost", reflect.TypeOf((*MockService)(nil).PurgePost), ctx, id)
+}
+
+// RestorePost mocks base method.
+func (m *MockService) RestorePost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestorePost", ctx, id)
+	ret0, _ := ret[0].(*entity.PostDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestorePost indicates an expected call of RestorePost.
+func (mr *MockServiceMockRecorder) RestorePost(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestorePost", reflect.TypeOf((*MockService)(nil).RestorePost), ctx, id)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockService) RestoreRevision(ctx context.Context, postID uuid.UUID, request entity.RestoreRevisionRequest) (*entity.PostDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", ctx, postID, request)
+	ret0, _ := ret[0].(*entity.PostDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockServiceMockRecorder) RestoreRevision(ctx, postID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockService)(nil).RestoreRevision), ctx, postID, request)
+}
+
 // UpdatePost mocks base method.
 func (m *MockService) UpdatePost(ctx context.Context, id uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,146 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/logging"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/apikey"
+	"go.uber.org/zap"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mockgen/repository/apikey/apikey_repository.go -source=./apikey_repository.go Repository
+type Repository interface {
+	CreateAPIKey(ctx context.Context, request entity.CreateAPIKeyRequest) (*entity.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+}
+
+const resourceAPIKey = "api key"
+
+type repositoryImplementation struct {
+	entClient *ent.Client
+	logger    *zap.Logger
+}
+
+type RepoConfiguration func(r *repositoryImplementation)
+
+func NewRepository(configs ...RepoConfiguration) Repository {
+	r := repositoryImplementation{}
+	for _, config := range configs {
+		config(&r)
+	}
+	return &r
+}
+
+func WithLogger(logger *zap.Logger) RepoConfiguration {
+	return func(r *repositoryImplementation) {
+		r.logger = logger
+	}
+}
+
+// log returns the logger of the call ctx belongs to, or the repository logger.
+func (r *repositoryImplementation) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, r.logger)
+}
+
+func WithEntClient(client *ent.Client) RepoConfiguration {
+	return func(r *repositoryImplementation) {
+		r.entClient = client
+	}
+}
+
+func (r *repositoryImplementation) CreateAPIKey(ctx context.Context,
+	request entity.CreateAPIKeyRequest) (*entity.APIKey, error) {
+	resp, err := r.entClient.ApiKey.Create().SetName(request.Name).SetKeyHash(request.KeyHash).
+		SetKeyPrefix(request.KeyPrefix).SetScopes(request.Scopes).SetCreatedBy(request.CreatedBy).
+		SetNillableExpiresAt(request.ExpiresAt).Save(ctx)
+	if err != nil {
+		err = toDomainError(err, request.Name)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in saving api key", zap.Error(err), zap.String("name", request.Name))
+		}
+		return nil, err
+	}
+	return decorateAPIKeyEntity(*resp), nil
+}
+
+// GetAPIKeyByHash returns the key with the given hash, revoked and expired
+// keys included.
+func (r *repositoryImplementation) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	resp, err := r.entClient.ApiKey.Query().Where(apikey.KeyHash(keyHash)).Only(ctx)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			r.log(ctx).Error("error in fetching api key", zap.Error(err))
+		}
+		return nil, toDomainError(err, "")
+	}
+	return decorateAPIKeyEntity(*resp), nil
+}
+
+func (r *repositoryImplementation) ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	resp, err := r.entClient.ApiKey.Query().Order(ent.Asc(apikey.FieldCreatedAt), ent.Asc(apikey.FieldName)).All(ctx)
+	if err != nil {
+		r.log(ctx).Error("error in listing api keys", zap.Error(err))
+		return nil, err
+	}
+	keys := make([]*entity.APIKey, 0, len(resp))
+	for _, k := range resp {
+		keys = append(keys, decorateAPIKeyEntity(*k))
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key. Revoking a revoked key succeeds and keeps the
+// original revocation time.
+func (r *repositoryImplementation) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	err := r.entClient.ApiKey.Update().Where(apikey.ID(id), apikey.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).Exec(ctx)
+	if err != nil {
+		r.log(ctx).Error("error in revoking api key", zap.Error(err), zap.Any("apiKeyID", id))
+		return nil, toDomainError(err, id.String())
+	}
+	resp, err := r.entClient.ApiKey.Get(ctx, id)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			r.log(ctx).Error("error in fetching api key", zap.Error(err), zap.Any("apiKeyID", id))
+		}
+		return nil, toDomainError(err, id.String())
+	}
+	return decorateAPIKeyEntity(*resp), nil
+}
+
+// toDomainError maps ent errors onto domain errors, anything unexpected is
+// returned untouched.
+func toDomainError(err error, id string) error {
+	var domainErr *domainerror.Error
+	switch {
+	case errors.As(err, &domainErr):
+		return err
+	case ent.IsNotFound(err):
+		return domainerror.NotFound(resourceAPIKey, id).Wrap(err)
+	case ent.IsConstraintError(err):
+		return domainerror.AlreadyExists(resourceAPIKey, id).Wrap(err)
+	case ent.IsValidationError(err):
+		return domainerror.InvalidArgument(err.Error()).Wrap(err)
+	}
+	return err
+}
+
+func decorateAPIKeyEntity(keyEnt ent.ApiKey) *entity.APIKey {
+	return &entity.APIKey{
+		ID:        keyEnt.ID,
+		Name:      keyEnt.Name,
+		KeyPrefix: keyEnt.KeyPrefix,
+		Scopes:    keyEnt.Scopes,
+		CreatedBy: keyEnt.CreatedBy,
+		ExpiresAt: keyEnt.ExpiresAt,
+		RevokedAt: keyEnt.RevokedAt,
+		CreatedAt: keyEnt.CreatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+package apikey
+
+import (
+	"context"
+	"entgo.io/ent/dialect"
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/enttest"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func newTestRepository(t *testing.T) *repositoryImplementation {
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return &repositoryImplementation{entClient: client, logger: zap.NewNop()}
+}
+
+func Test_repositoryImplementation_apiKeys(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	created, err := r.CreateAPIKey(ctx, entity.CreateAPIKeyRequest{Name: "importer", Scopes: []string{"author"},
+		ExpiresAt: &expiresAt, CreatedBy: "alice", KeyHash: "hash-1", KeyPrefix: "cbk_abcdefgh"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	if created.Name != "importer" || created.CreatedBy != "alice" || created.ExpiresAt == nil ||
+		created.RevokedAt != nil {
+		t.Errorf("CreateAPIKey() got = %+v", created)
+	}
+
+	_, err = r.CreateAPIKey(ctx, entity.CreateAPIKeyRequest{Name: "importer", Scopes: []string{"reader"},
+		KeyHash: "hash-2", KeyPrefix: "cbk_ijklmnop"})
+	if domainerror.KindOf(err) != domainerror.KindAlreadyExists {
+		t.Errorf("CreateAPIKey() with a taken name error = %v, want already exists", err)
+	}
+
+	got, err := r.GetAPIKeyByHash(ctx, "hash-1")
+	if err != nil || got.ID != created.ID {
+		t.Errorf("GetAPIKeyByHash() = %+v, %v, want %s", got, err, created.ID)
+	}
+	if _, err := r.GetAPIKeyByHash(ctx, "hash-2"); domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("GetAPIKeyByHash() error = %v, want not found", err)
+	}
+
+	revoked, err := r.RevokeAPIKey(ctx, created.ID)
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("RevokeAPIKey() = %+v, %v, want a revocation time", revoked, err)
+	}
+	again, err := r.RevokeAPIKey(ctx, created.ID)
+	if err != nil || !again.RevokedAt.Equal(*revoked.RevokedAt) {
+		t.Errorf("RevokeAPIKey() again = %+v, %v, want the original revocation time", again, err)
+	}
+	if _, err := r.RevokeAPIKey(ctx, uuid.New()); domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("RevokeAPIKey() error = %v, want not found", err)
+	}
+
+	keys, err := r.ListAPIKeys(ctx)
+	if err != nil || len(keys) != 1 || keys[0].RevokedAt == nil {
+		t.Errorf("ListAPIKeys() = %+v, %v, want the revoked key", keys, err)
+	}
+}
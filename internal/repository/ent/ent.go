@@ -12,7 +12,9 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/apikey"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -73,7 +75,9 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			post.Table: post.ValidColumn,
+			apikey.Table:       apikey.ValidColumn,
+			post.Table:         post.ValidColumn,
+			postrevision.Table: postrevision.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -25,19 +25,50 @@ type Post struct {
 	Content string `json:"content,omitempty"`
 	// Author holds the value of the "author" field.
 	Author string `json:"author,omitempty"`
+	// Owner holds the value of the "owner" field.
+	Owner string `json:"owner,omitempty"`
 	// PublishedOn holds the value of the "published_on" field.
 	PublishedOn time.Time `json:"published_on,omitempty"`
 	// Tags holds the value of the "tags" field.
 	Tags []string `json:"tags,omitempty"`
 	// IsDeleted holds the value of the "is_deleted" field.
 	IsDeleted bool `json:"is_deleted,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// DeletedBy holds the value of the "deleted_by" field.
+	DeletedBy *string `json:"deleted_by,omitempty"`
+	// Revision holds the value of the "revision" field.
+	Revision int64 `json:"revision,omitempty"`
+	// ChangedBy holds the value of the "changed_by" field.
+	ChangedBy string `json:"changed_by,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
-	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PostQuery when eager-loading is set.
+	Edges        PostEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// PostEdges holds the relations/edges for other nodes in the graph.
+type PostEdges struct {
+	// Revisions holds the value of the revisions edge.
+	Revisions []*PostRevision `json:"revisions,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// RevisionsOrErr returns the Revisions value or an error if the edge
+// was not loaded in eager-loading.
+func (e PostEdges) RevisionsOrErr() ([]*PostRevision, error) {
+	if e.loadedTypes[0] {
+		return e.Revisions, nil
+	}
+	return nil, &NotLoadedError{edge: "revisions"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Post) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -47,9 +78,11 @@ func (*Post) scanValues(columns []string) ([]any, error) {
 			values[i] = new([]byte)
 		case post.FieldIsDeleted:
 			values[i] = new(sql.NullBool)
-		case post.FieldTitle, post.FieldContent, post.FieldAuthor:
+		case post.FieldRevision:
+			values[i] = new(sql.NullInt64)
+		case post.FieldTitle, post.FieldContent, post.FieldAuthor, post.FieldOwner, post.FieldDeletedBy, post.FieldChangedBy:
 			values[i] = new(sql.NullString)
-		case post.FieldPublishedOn, post.FieldCreatedAt, post.FieldUpdatedAt:
+		case post.FieldPublishedOn, post.FieldDeletedAt, post.FieldCreatedAt, post.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case post.FieldID:
 			values[i] = new(uuid.UUID)
@@ -92,6 +125,12 @@ func (po *Post) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				po.Author = value.String
 			}
+		case post.FieldOwner:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field owner", values[i])
+			} else if value.Valid {
+				po.Owner = value.String
+			}
 		case post.FieldPublishedOn:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field published_on", values[i])
@@ -112,6 +151,32 @@ func (po *Post) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				po.IsDeleted = value.Bool
 			}
+		case post.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				po.DeletedAt = new(time.Time)
+				*po.DeletedAt = value.Time
+			}
+		case post.FieldDeletedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_by", values[i])
+			} else if value.Valid {
+				po.DeletedBy = new(string)
+				*po.DeletedBy = value.String
+			}
+		case post.FieldRevision:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field revision", values[i])
+			} else if value.Valid {
+				po.Revision = value.Int64
+			}
+		case post.FieldChangedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field changed_by", values[i])
+			} else if value.Valid {
+				po.ChangedBy = value.String
+			}
 		case post.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -137,6 +202,11 @@ func (po *Post) Value(name string) (ent.Value, error) {
 	return po.selectValues.Get(name)
 }
 
+// QueryRevisions queries the "revisions" edge of the Post entity.
+func (po *Post) QueryRevisions() *PostRevisionQuery {
+	return NewPostClient(po.config).QueryRevisions(po)
+}
+
 // Update returns a builder for updating this Post.
 // Note that you need to call Post.Unwrap() before calling this method if this Post
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -169,6 +239,9 @@ func (po *Post) String() string {
 	builder.WriteString("author=")
 	builder.WriteString(po.Author)
 	builder.WriteString(", ")
+	builder.WriteString("owner=")
+	builder.WriteString(po.Owner)
+	builder.WriteString(", ")
 	builder.WriteString("published_on=")
 	builder.WriteString(po.PublishedOn.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -178,6 +251,22 @@ func (po *Post) String() string {
 	builder.WriteString("is_deleted=")
 	builder.WriteString(fmt.Sprintf("%v", po.IsDeleted))
 	builder.WriteString(", ")
+	if v := po.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := po.DeletedBy; v != nil {
+		builder.WriteString("deleted_by=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("revision=")
+	builder.WriteString(fmt.Sprintf("%v", po.Revision))
+	builder.WriteString(", ")
+	builder.WriteString("changed_by=")
+	builder.WriteString(po.ChangedBy)
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(po.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -14,6 +14,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
 )
 
 // PostCreate is the builder for creating a Post entity.
@@ -42,6 +43,20 @@ func (pc *PostCreate) SetAuthor(s string) *PostCreate {
 	return pc
 }
 
+// SetOwner sets the "owner" field.
+func (pc *PostCreate) SetOwner(s string) *PostCreate {
+	pc.mutation.SetOwner(s)
+	return pc
+}
+
+// SetNillableOwner sets the "owner" field if the given value is not nil.
+func (pc *PostCreate) SetNillableOwner(s *string) *PostCreate {
+	if s != nil {
+		pc.SetOwner(*s)
+	}
+	return pc
+}
+
 // SetPublishedOn sets the "published_on" field.
 func (pc *PostCreate) SetPublishedOn(t time.Time) *PostCreate {
 	pc.mutation.SetPublishedOn(t)
@@ -68,6 +83,62 @@ func (pc *PostCreate) SetNillableIsDeleted(b *bool) *PostCreate {
 	return pc
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (pc *PostCreate) SetDeletedAt(t time.Time) *PostCreate {
+	pc.mutation.SetDeletedAt(t)
+	return pc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (pc *PostCreate) SetNillableDeletedAt(t *time.Time) *PostCreate {
+	if t != nil {
+		pc.SetDeletedAt(*t)
+	}
+	return pc
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (pc *PostCreate) SetDeletedBy(s string) *PostCreate {
+	pc.mutation.SetDeletedBy(s)
+	return pc
+}
+
+// SetNillableDeletedBy sets the "deleted_by" field if the given value is not nil.
+func (pc *PostCreate) SetNillableDeletedBy(s *string) *PostCreate {
+	if s != nil {
+		pc.SetDeletedBy(*s)
+	}
+	return pc
+}
+
+// SetRevision sets the "revision" field.
+func (pc *PostCreate) SetRevision(i int64) *PostCreate {
+	pc.mutation.SetRevision(i)
+	return pc
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (pc *PostCreate) SetNillableRevision(i *int64) *PostCreate {
+	if i != nil {
+		pc.SetRevision(*i)
+	}
+	return pc
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (pc *PostCreate) SetChangedBy(s string) *PostCreate {
+	pc.mutation.SetChangedBy(s)
+	return pc
+}
+
+// SetNillableChangedBy sets the "changed_by" field if the given value is not nil.
+func (pc *PostCreate) SetNillableChangedBy(s *string) *PostCreate {
+	if s != nil {
+		pc.SetChangedBy(*s)
+	}
+	return pc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (pc *PostCreate) SetCreatedAt(t time.Time) *PostCreate {
 	pc.mutation.SetCreatedAt(t)
@@ -110,6 +181,21 @@ func (pc *PostCreate) SetNillableID(u *uuid.UUID) *PostCreate {
 	return pc
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PostRevision entity by IDs.
+func (pc *PostCreate) AddRevisionIDs(ids ...uuid.UUID) *PostCreate {
+	pc.mutation.AddRevisionIDs(ids...)
+	return pc
+}
+
+// AddRevisions adds the "revisions" edges to the PostRevision entity.
+func (pc *PostCreate) AddRevisions(p ...*PostRevision) *PostCreate {
+	ids := make([]uuid.UUID, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pc.AddRevisionIDs(ids...)
+}
+
 // Mutation returns the PostMutation object of the builder.
 func (pc *PostCreate) Mutation() *PostMutation {
 	return pc.mutation
@@ -117,7 +203,9 @@ func (pc *PostCreate) Mutation() *PostMutation {
 
 // Save creates the Post in the database.
 func (pc *PostCreate) Save(ctx context.Context) (*Post, error) {
-	pc.defaults()
+	if err := pc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, pc.sqlSave, pc.mutation, pc.hooks)
 }
 
@@ -144,23 +232,45 @@ func (pc *PostCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (pc *PostCreate) defaults() {
+func (pc *PostCreate) defaults() error {
+	if _, ok := pc.mutation.Owner(); !ok {
+		v := post.DefaultOwner
+		pc.mutation.SetOwner(v)
+	}
 	if _, ok := pc.mutation.IsDeleted(); !ok {
 		v := post.DefaultIsDeleted
 		pc.mutation.SetIsDeleted(v)
 	}
+	if _, ok := pc.mutation.Revision(); !ok {
+		v := post.DefaultRevision
+		pc.mutation.SetRevision(v)
+	}
+	if _, ok := pc.mutation.ChangedBy(); !ok {
+		v := post.DefaultChangedBy
+		pc.mutation.SetChangedBy(v)
+	}
 	if _, ok := pc.mutation.CreatedAt(); !ok {
+		if post.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized post.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
 		v := post.DefaultCreatedAt()
 		pc.mutation.SetCreatedAt(v)
 	}
 	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		if post.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized post.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := post.DefaultUpdatedAt()
 		pc.mutation.SetUpdatedAt(v)
 	}
 	if _, ok := pc.mutation.ID(); !ok {
+		if post.DefaultID == nil {
+			return fmt.Errorf("ent: uninitialized post.DefaultID (forgotten import ent/runtime?)")
+		}
 		v := post.DefaultID()
 		pc.mutation.SetID(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -174,6 +284,9 @@ func (pc *PostCreate) check() error {
 	if _, ok := pc.mutation.Author(); !ok {
 		return &ValidationError{Name: "author", err: errors.New(`ent: missing required field "Post.author"`)}
 	}
+	if _, ok := pc.mutation.Owner(); !ok {
+		return &ValidationError{Name: "owner", err: errors.New(`ent: missing required field "Post.owner"`)}
+	}
 	if _, ok := pc.mutation.PublishedOn(); !ok {
 		return &ValidationError{Name: "published_on", err: errors.New(`ent: missing required field "Post.published_on"`)}
 	}
@@ -183,6 +296,12 @@ func (pc *PostCreate) check() error {
 	if _, ok := pc.mutation.IsDeleted(); !ok {
 		return &ValidationError{Name: "is_deleted", err: errors.New(`ent: missing required field "Post.is_deleted"`)}
 	}
+	if _, ok := pc.mutation.Revision(); !ok {
+		return &ValidationError{Name: "revision", err: errors.New(`ent: missing required field "Post.revision"`)}
+	}
+	if _, ok := pc.mutation.ChangedBy(); !ok {
+		return &ValidationError{Name: "changed_by", err: errors.New(`ent: missing required field "Post.changed_by"`)}
+	}
 	if _, ok := pc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Post.created_at"`)}
 	}
@@ -237,6 +356,10 @@ func (pc *PostCreate) createSpec() (*Post, *sqlgraph.CreateSpec) {
 		_spec.SetField(post.FieldAuthor, field.TypeString, value)
 		_node.Author = value
 	}
+	if value, ok := pc.mutation.Owner(); ok {
+		_spec.SetField(post.FieldOwner, field.TypeString, value)
+		_node.Owner = value
+	}
 	if value, ok := pc.mutation.PublishedOn(); ok {
 		_spec.SetField(post.FieldPublishedOn, field.TypeTime, value)
 		_node.PublishedOn = value
@@ -249,6 +372,22 @@ func (pc *PostCreate) createSpec() (*Post, *sqlgraph.CreateSpec) {
 		_spec.SetField(post.FieldIsDeleted, field.TypeBool, value)
 		_node.IsDeleted = value
 	}
+	if value, ok := pc.mutation.DeletedAt(); ok {
+		_spec.SetField(post.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := pc.mutation.DeletedBy(); ok {
+		_spec.SetField(post.FieldDeletedBy, field.TypeString, value)
+		_node.DeletedBy = &value
+	}
+	if value, ok := pc.mutation.Revision(); ok {
+		_spec.SetField(post.FieldRevision, field.TypeInt64, value)
+		_node.Revision = value
+	}
+	if value, ok := pc.mutation.ChangedBy(); ok {
+		_spec.SetField(post.FieldChangedBy, field.TypeString, value)
+		_node.ChangedBy = value
+	}
 	if value, ok := pc.mutation.CreatedAt(); ok {
 		_spec.SetField(post.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -257,6 +396,22 @@ func (pc *PostCreate) createSpec() (*Post, *sqlgraph.CreateSpec) {
 		_spec.SetField(post.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
+	if nodes := pc.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -381,6 +536,72 @@ func (u *PostUpsert) UpdateIsDeleted() *PostUpsert {
 	return u
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (u *PostUpsert) SetDeletedAt(v time.Time) *PostUpsert {
+	u.Set(post.FieldDeletedAt, v)
+	return u
+}
+
+// UpdateDeletedAt sets the "deleted_at" field to the value that was provided on create.
+func (u *PostUpsert) UpdateDeletedAt() *PostUpsert {
+	u.SetExcluded(post.FieldDeletedAt)
+	return u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (u *PostUpsert) ClearDeletedAt() *PostUpsert {
+	u.SetNull(post.FieldDeletedAt)
+	return u
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (u *PostUpsert) SetDeletedBy(v string) *PostUpsert {
+	u.Set(post.FieldDeletedBy, v)
+	return u
+}
+
+// UpdateDeletedBy sets the "deleted_by" field to the value that was provided on create.
+func (u *PostUpsert) UpdateDeletedBy() *PostUpsert {
+	u.SetExcluded(post.FieldDeletedBy)
+	return u
+}
+
+// ClearDeletedBy clears the value of the "deleted_by" field.
+func (u *PostUpsert) ClearDeletedBy() *PostUpsert {
+	u.SetNull(post.FieldDeletedBy)
+	return u
+}
+
+// SetRevision sets the "revision" field.
+func (u *PostUpsert) SetRevision(v int64) *PostUpsert {
+	u.Set(post.FieldRevision, v)
+	return u
+}
+
+// UpdateRevision sets the "revision" field to the value that was provided on create.
+func (u *PostUpsert) UpdateRevision() *PostUpsert {
+	u.SetExcluded(post.FieldRevision)
+	return u
+}
+
+// AddRevision adds v to the "revision" field.
+func (u *PostUpsert) AddRevision(v int64) *PostUpsert {
+	u.Add(post.FieldRevision, v)
+	return u
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (u *PostUpsert) SetChangedBy(v string) *PostUpsert {
+	u.Set(post.FieldChangedBy, v)
+	return u
+}
+
+// UpdateChangedBy sets the "changed_by" field to the value that was provided on create.
+func (u *PostUpsert) UpdateChangedBy() *PostUpsert {
+	u.SetExcluded(post.FieldChangedBy)
+	return u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *PostUpsert) SetCreatedAt(v time.Time) *PostUpsert {
 	u.Set(post.FieldCreatedAt, v)
@@ -422,6 +643,9 @@ func (u *PostUpsertOne) UpdateNewValues() *PostUpsertOne {
 		if _, exists := u.create.mutation.ID(); exists {
 			s.SetIgnore(post.FieldID)
 		}
+		if _, exists := u.create.mutation.Owner(); exists {
+			s.SetIgnore(post.FieldOwner)
+		}
 	}))
 	return u
 }
@@ -537,6 +761,83 @@ func (u *PostUpsertOne) UpdateIsDeleted() *PostUpsertOne {
 	})
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (u *PostUpsertOne) SetDeletedAt(v time.Time) *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.SetDeletedAt(v)
+	})
+}
+
+// UpdateDeletedAt sets the "deleted_at" field to the value that was provided on create.
+func (u *PostUpsertOne) UpdateDeletedAt() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateDeletedAt()
+	})
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (u *PostUpsertOne) ClearDeletedAt() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.ClearDeletedAt()
+	})
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (u *PostUpsertOne) SetDeletedBy(v string) *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.SetDeletedBy(v)
+	})
+}
+
+// UpdateDeletedBy sets the "deleted_by" field to the value that was provided on create.
+func (u *PostUpsertOne) UpdateDeletedBy() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateDeletedBy()
+	})
+}
+
+// ClearDeletedBy clears the value of the "deleted_by" field.
+func (u *PostUpsertOne) ClearDeletedBy() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.ClearDeletedBy()
+	})
+}
+
+// SetRevision sets the "revision" field.
+func (u *PostUpsertOne) SetRevision(v int64) *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.SetRevision(v)
+	})
+}
+
+// AddRevision adds v to the "revision" field.
+func (u *PostUpsertOne) AddRevision(v int64) *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.AddRevision(v)
+	})
+}
+
+// UpdateRevision sets the "revision" field to the value that was provided on create.
+func (u *PostUpsertOne) UpdateRevision() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateRevision()
+	})
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (u *PostUpsertOne) SetChangedBy(v string) *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.SetChangedBy(v)
+	})
+}
+
+// UpdateChangedBy sets the "changed_by" field to the value that was provided on create.
+func (u *PostUpsertOne) UpdateChangedBy() *PostUpsertOne {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateChangedBy()
+	})
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *PostUpsertOne) SetCreatedAt(v time.Time) *PostUpsertOne {
 	return u.Update(func(s *PostUpsert) {
@@ -748,6 +1049,9 @@ func (u *PostUpsertBulk) UpdateNewValues() *PostUpsertBulk {
 			if _, exists := b.mutation.ID(); exists {
 				s.SetIgnore(post.FieldID)
 			}
+			if _, exists := b.mutation.Owner(); exists {
+				s.SetIgnore(post.FieldOwner)
+			}
 		}
 	}))
 	return u
@@ -864,6 +1168,83 @@ func (u *PostUpsertBulk) UpdateIsDeleted() *PostUpsertBulk {
 	})
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (u *PostUpsertBulk) SetDeletedAt(v time.Time) *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.SetDeletedAt(v)
+	})
+}
+
+// UpdateDeletedAt sets the "deleted_at" field to the value that was provided on create.
+func (u *PostUpsertBulk) UpdateDeletedAt() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateDeletedAt()
+	})
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (u *PostUpsertBulk) ClearDeletedAt() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.ClearDeletedAt()
+	})
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (u *PostUpsertBulk) SetDeletedBy(v string) *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.SetDeletedBy(v)
+	})
+}
+
+// UpdateDeletedBy sets the "deleted_by" field to the value that was provided on create.
+func (u *PostUpsertBulk) UpdateDeletedBy() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateDeletedBy()
+	})
+}
+
+// ClearDeletedBy clears the value of the "deleted_by" field.
+func (u *PostUpsertBulk) ClearDeletedBy() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.ClearDeletedBy()
+	})
+}
+
+// SetRevision sets the "revision" field.
+func (u *PostUpsertBulk) SetRevision(v int64) *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.SetRevision(v)
+	})
+}
+
+// AddRevision adds v to the "revision" field.
+func (u *PostUpsertBulk) AddRevision(v int64) *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.AddRevision(v)
+	})
+}
+
+// UpdateRevision sets the "revision" field to the value that was provided on create.
+func (u *PostUpsertBulk) UpdateRevision() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateRevision()
+	})
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (u *PostUpsertBulk) SetChangedBy(v string) *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.SetChangedBy(v)
+	})
+}
+
+// UpdateChangedBy sets the "changed_by" field to the value that was provided on create.
+func (u *PostUpsertBulk) UpdateChangedBy() *PostUpsertBulk {
+	return u.Update(func(s *PostUpsert) {
+		s.UpdateChangedBy()
+	})
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (u *PostUpsertBulk) SetCreatedAt(v time.Time) *PostUpsertBulk {
 	return u.Update(func(s *PostUpsert) {
@@ -12,7 +12,9 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent/predicate"
 )
 
@@ -112,6 +114,81 @@ func (pu *PostUpdate) SetNillableIsDeleted(b *bool) *PostUpdate {
 	return pu
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (pu *PostUpdate) SetDeletedAt(t time.Time) *PostUpdate {
+	pu.mutation.SetDeletedAt(t)
+	return pu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (pu *PostUpdate) SetNillableDeletedAt(t *time.Time) *PostUpdate {
+	if t != nil {
+		pu.SetDeletedAt(*t)
+	}
+	return pu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (pu *PostUpdate) ClearDeletedAt() *PostUpdate {
+	pu.mutation.ClearDeletedAt()
+	return pu
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (pu *PostUpdate) SetDeletedBy(s string) *PostUpdate {
+	pu.mutation.SetDeletedBy(s)
+	return pu
+}
+
+// SetNillableDeletedBy sets the "deleted_by" field if the given value is not nil.
+func (pu *PostUpdate) SetNillableDeletedBy(s *string) *PostUpdate {
+	if s != nil {
+		pu.SetDeletedBy(*s)
+	}
+	return pu
+}
+
+// ClearDeletedBy clears the value of the "deleted_by" field.
+func (pu *PostUpdate) ClearDeletedBy() *PostUpdate {
+	pu.mutation.ClearDeletedBy()
+	return pu
+}
+
+// SetRevision sets the "revision" field.
+func (pu *PostUpdate) SetRevision(i int64) *PostUpdate {
+	pu.mutation.ResetRevision()
+	pu.mutation.SetRevision(i)
+	return pu
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (pu *PostUpdate) SetNillableRevision(i *int64) *PostUpdate {
+	if i != nil {
+		pu.SetRevision(*i)
+	}
+	return pu
+}
+
+// AddRevision adds i to the "revision" field.
+func (pu *PostUpdate) AddRevision(i int64) *PostUpdate {
+	pu.mutation.AddRevision(i)
+	return pu
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (pu *PostUpdate) SetChangedBy(s string) *PostUpdate {
+	pu.mutation.SetChangedBy(s)
+	return pu
+}
+
+// SetNillableChangedBy sets the "changed_by" field if the given value is not nil.
+func (pu *PostUpdate) SetNillableChangedBy(s *string) *PostUpdate {
+	if s != nil {
+		pu.SetChangedBy(*s)
+	}
+	return pu
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (pu *PostUpdate) SetCreatedAt(t time.Time) *PostUpdate {
 	pu.mutation.SetCreatedAt(t)
@@ -132,14 +209,52 @@ func (pu *PostUpdate) SetUpdatedAt(t time.Time) *PostUpdate {
 	return pu
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PostRevision entity by IDs.
+func (pu *PostUpdate) AddRevisionIDs(ids ...uuid.UUID) *PostUpdate {
+	pu.mutation.AddRevisionIDs(ids...)
+	return pu
+}
+
+// AddRevisions adds the "revisions" edges to the PostRevision entity.
+func (pu *PostUpdate) AddRevisions(p ...*PostRevision) *PostUpdate {
+	ids := make([]uuid.UUID, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pu.AddRevisionIDs(ids...)
+}
+
 // Mutation returns the PostMutation object of the builder.
 func (pu *PostUpdate) Mutation() *PostMutation {
 	return pu.mutation
 }
 
+// ClearRevisions clears all "revisions" edges to the PostRevision entity.
+func (pu *PostUpdate) ClearRevisions() *PostUpdate {
+	pu.mutation.ClearRevisions()
+	return pu
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to PostRevision entities by IDs.
+func (pu *PostUpdate) RemoveRevisionIDs(ids ...uuid.UUID) *PostUpdate {
+	pu.mutation.RemoveRevisionIDs(ids...)
+	return pu
+}
+
+// RemoveRevisions removes "revisions" edges to PostRevision entities.
+func (pu *PostUpdate) RemoveRevisions(p ...*PostRevision) *PostUpdate {
+	ids := make([]uuid.UUID, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return pu.RemoveRevisionIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pu *PostUpdate) Save(ctx context.Context) (int, error) {
-	pu.defaults()
+	if err := pu.defaults(); err != nil {
+		return 0, err
+	}
 	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
 }
 
@@ -166,11 +281,15 @@ func (pu *PostUpdate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (pu *PostUpdate) defaults() {
+func (pu *PostUpdate) defaults() error {
 	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		if post.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized post.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := post.UpdateDefaultUpdatedAt()
 		pu.mutation.SetUpdatedAt(v)
 	}
+	return nil
 }
 
 // Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
@@ -211,12 +330,78 @@ func (pu *PostUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := pu.mutation.IsDeleted(); ok {
 		_spec.SetField(post.FieldIsDeleted, field.TypeBool, value)
 	}
+	if value, ok := pu.mutation.DeletedAt(); ok {
+		_spec.SetField(post.FieldDeletedAt, field.TypeTime, value)
+	}
+	if pu.mutation.DeletedAtCleared() {
+		_spec.ClearField(post.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.DeletedBy(); ok {
+		_spec.SetField(post.FieldDeletedBy, field.TypeString, value)
+	}
+	if pu.mutation.DeletedByCleared() {
+		_spec.ClearField(post.FieldDeletedBy, field.TypeString)
+	}
+	if value, ok := pu.mutation.Revision(); ok {
+		_spec.SetField(post.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := pu.mutation.AddedRevision(); ok {
+		_spec.AddField(post.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := pu.mutation.ChangedBy(); ok {
+		_spec.SetField(post.FieldChangedBy, field.TypeString, value)
+	}
 	if value, ok := pu.mutation.CreatedAt(); ok {
 		_spec.SetField(post.FieldCreatedAt, field.TypeTime, value)
 	}
 	if value, ok := pu.mutation.UpdatedAt(); ok {
 		_spec.SetField(post.FieldUpdatedAt, field.TypeTime, value)
 	}
+	if pu.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !pu.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_spec.AddModifiers(pu.modifiers...)
 	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
@@ -321,6 +506,81 @@ func (puo *PostUpdateOne) SetNillableIsDeleted(b *bool) *PostUpdateOne {
 	return puo
 }
 
+// SetDeletedAt sets the "deleted_at" field.
+func (puo *PostUpdateOne) SetDeletedAt(t time.Time) *PostUpdateOne {
+	puo.mutation.SetDeletedAt(t)
+	return puo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (puo *PostUpdateOne) SetNillableDeletedAt(t *time.Time) *PostUpdateOne {
+	if t != nil {
+		puo.SetDeletedAt(*t)
+	}
+	return puo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (puo *PostUpdateOne) ClearDeletedAt() *PostUpdateOne {
+	puo.mutation.ClearDeletedAt()
+	return puo
+}
+
+// SetDeletedBy sets the "deleted_by" field.
+func (puo *PostUpdateOne) SetDeletedBy(s string) *PostUpdateOne {
+	puo.mutation.SetDeletedBy(s)
+	return puo
+}
+
+// SetNillableDeletedBy sets the "deleted_by" field if the given value is not nil.
+func (puo *PostUpdateOne) SetNillableDeletedBy(s *string) *PostUpdateOne {
+	if s != nil {
+		puo.SetDeletedBy(*s)
+	}
+	return puo
+}
+
+// ClearDeletedBy clears the value of the "deleted_by" field.
+func (puo *PostUpdateOne) ClearDeletedBy() *PostUpdateOne {
+	puo.mutation.ClearDeletedBy()
+	return puo
+}
+
+// SetRevision sets the "revision" field.
+func (puo *PostUpdateOne) SetRevision(i int64) *PostUpdateOne {
+	puo.mutation.ResetRevision()
+	puo.mutation.SetRevision(i)
+	return puo
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (puo *PostUpdateOne) SetNillableRevision(i *int64) *PostUpdateOne {
+	if i != nil {
+		puo.SetRevision(*i)
+	}
+	return puo
+}
+
+// AddRevision adds i to the "revision" field.
+func (puo *PostUpdateOne) AddRevision(i int64) *PostUpdateOne {
+	puo.mutation.AddRevision(i)
+	return puo
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (puo *PostUpdateOne) SetChangedBy(s string) *PostUpdateOne {
+	puo.mutation.SetChangedBy(s)
+	return puo
+}
+
+// SetNillableChangedBy sets the "changed_by" field if the given value is not nil.
+func (puo *PostUpdateOne) SetNillableChangedBy(s *string) *PostUpdateOne {
+	if s != nil {
+		puo.SetChangedBy(*s)
+	}
+	return puo
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (puo *PostUpdateOne) SetCreatedAt(t time.Time) *PostUpdateOne {
 	puo.mutation.SetCreatedAt(t)
@@ -341,11 +601,47 @@ func (puo *PostUpdateOne) SetUpdatedAt(t time.Time) *PostUpdateOne {
 	return puo
 }
 
+// AddRevisionIDs adds the "revisions" edge to the PostRevision entity by IDs.
+func (puo *PostUpdateOne) AddRevisionIDs(ids ...uuid.UUID) *PostUpdateOne {
+	puo.mutation.AddRevisionIDs(ids...)
+	return puo
+}
+
+// AddRevisions adds the "revisions" edges to the PostRevision entity.
+func (puo *PostUpdateOne) AddRevisions(p ...*PostRevision) *PostUpdateOne {
+	ids := make([]uuid.UUID, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return puo.AddRevisionIDs(ids...)
+}
+
 // Mutation returns the PostMutation object of the builder.
 func (puo *PostUpdateOne) Mutation() *PostMutation {
 	return puo.mutation
 }
 
+// ClearRevisions clears all "revisions" edges to the PostRevision entity.
+func (puo *PostUpdateOne) ClearRevisions() *PostUpdateOne {
+	puo.mutation.ClearRevisions()
+	return puo
+}
+
+// RemoveRevisionIDs removes the "revisions" edge to PostRevision entities by IDs.
+func (puo *PostUpdateOne) RemoveRevisionIDs(ids ...uuid.UUID) *PostUpdateOne {
+	puo.mutation.RemoveRevisionIDs(ids...)
+	return puo
+}
+
+// RemoveRevisions removes "revisions" edges to PostRevision entities.
+func (puo *PostUpdateOne) RemoveRevisions(p ...*PostRevision) *PostUpdateOne {
+	ids := make([]uuid.UUID, len(p))
+	for i := range p {
+		ids[i] = p[i].ID
+	}
+	return puo.RemoveRevisionIDs(ids...)
+}
+
 // Where appends a list predicates to the PostUpdate builder.
 func (puo *PostUpdateOne) Where(ps ...predicate.Post) *PostUpdateOne {
 	puo.mutation.Where(ps...)
@@ -361,7 +657,9 @@ func (puo *PostUpdateOne) Select(field string, fields ...string) *PostUpdateOne
 
 // Save executes the query and returns the updated Post entity.
 func (puo *PostUpdateOne) Save(ctx context.Context) (*Post, error) {
-	puo.defaults()
+	if err := puo.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, puo.sqlSave, puo.mutation, puo.hooks)
 }
 
@@ -388,11 +686,15 @@ func (puo *PostUpdateOne) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (puo *PostUpdateOne) defaults() {
+func (puo *PostUpdateOne) defaults() error {
 	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		if post.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized post.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := post.UpdateDefaultUpdatedAt()
 		puo.mutation.SetUpdatedAt(v)
 	}
+	return nil
 }
 
 // Modify adds a statement modifier for attaching custom logic to the UPDATE statement.
@@ -450,12 +752,78 @@ func (puo *PostUpdateOne) sqlSave(ctx context.Context) (_node *Post, err error)
 	if value, ok := puo.mutation.IsDeleted(); ok {
 		_spec.SetField(post.FieldIsDeleted, field.TypeBool, value)
 	}
+	if value, ok := puo.mutation.DeletedAt(); ok {
+		_spec.SetField(post.FieldDeletedAt, field.TypeTime, value)
+	}
+	if puo.mutation.DeletedAtCleared() {
+		_spec.ClearField(post.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.DeletedBy(); ok {
+		_spec.SetField(post.FieldDeletedBy, field.TypeString, value)
+	}
+	if puo.mutation.DeletedByCleared() {
+		_spec.ClearField(post.FieldDeletedBy, field.TypeString)
+	}
+	if value, ok := puo.mutation.Revision(); ok {
+		_spec.SetField(post.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := puo.mutation.AddedRevision(); ok {
+		_spec.AddField(post.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := puo.mutation.ChangedBy(); ok {
+		_spec.SetField(post.FieldChangedBy, field.TypeString, value)
+	}
 	if value, ok := puo.mutation.CreatedAt(); ok {
 		_spec.SetField(post.FieldCreatedAt, field.TypeTime, value)
 	}
 	if value, ok := puo.mutation.UpdatedAt(); ok {
 		_spec.SetField(post.FieldUpdatedAt, field.TypeTime, value)
 	}
+	if puo.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedRevisionsIDs(); len(nodes) > 0 && !puo.mutation.RevisionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RevisionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   post.RevisionsTable,
+			Columns: []string{post.RevisionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_spec.AddModifiers(puo.modifiers...)
 	_node = &Post{config: puo.config}
 	_spec.Assign = _node.assignValues
@@ -0,0 +1,673 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
+)
+
+// PostRevisionCreate is the builder for creating a PostRevision entity.
+type PostRevisionCreate struct {
+	config
+	mutation *PostRevisionMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetPostID sets the "post_id" field.
+func (prc *PostRevisionCreate) SetPostID(u uuid.UUID) *PostRevisionCreate {
+	prc.mutation.SetPostID(u)
+	return prc
+}
+
+// SetRevision sets the "revision" field.
+func (prc *PostRevisionCreate) SetRevision(i int64) *PostRevisionCreate {
+	prc.mutation.SetRevision(i)
+	return prc
+}
+
+// SetTitle sets the "title" field.
+func (prc *PostRevisionCreate) SetTitle(s string) *PostRevisionCreate {
+	prc.mutation.SetTitle(s)
+	return prc
+}
+
+// SetContent sets the "content" field.
+func (prc *PostRevisionCreate) SetContent(s string) *PostRevisionCreate {
+	prc.mutation.SetContent(s)
+	return prc
+}
+
+// SetAuthor sets the "author" field.
+func (prc *PostRevisionCreate) SetAuthor(s string) *PostRevisionCreate {
+	prc.mutation.SetAuthor(s)
+	return prc
+}
+
+// SetPublishedOn sets the "published_on" field.
+func (prc *PostRevisionCreate) SetPublishedOn(t time.Time) *PostRevisionCreate {
+	prc.mutation.SetPublishedOn(t)
+	return prc
+}
+
+// SetTags sets the "tags" field.
+func (prc *PostRevisionCreate) SetTags(s []string) *PostRevisionCreate {
+	prc.mutation.SetTags(s)
+	return prc
+}
+
+// SetChangedBy sets the "changed_by" field.
+func (prc *PostRevisionCreate) SetChangedBy(s string) *PostRevisionCreate {
+	prc.mutation.SetChangedBy(s)
+	return prc
+}
+
+// SetNillableChangedBy sets the "changed_by" field if the given value is not nil.
+func (prc *PostRevisionCreate) SetNillableChangedBy(s *string) *PostRevisionCreate {
+	if s != nil {
+		prc.SetChangedBy(*s)
+	}
+	return prc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (prc *PostRevisionCreate) SetCreatedAt(t time.Time) *PostRevisionCreate {
+	prc.mutation.SetCreatedAt(t)
+	return prc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (prc *PostRevisionCreate) SetNillableCreatedAt(t *time.Time) *PostRevisionCreate {
+	if t != nil {
+		prc.SetCreatedAt(*t)
+	}
+	return prc
+}
+
+// SetID sets the "id" field.
+func (prc *PostRevisionCreate) SetID(u uuid.UUID) *PostRevisionCreate {
+	prc.mutation.SetID(u)
+	return prc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (prc *PostRevisionCreate) SetNillableID(u *uuid.UUID) *PostRevisionCreate {
+	if u != nil {
+		prc.SetID(*u)
+	}
+	return prc
+}
+
+// SetPost sets the "post" edge to the Post entity.
+func (prc *PostRevisionCreate) SetPost(p *Post) *PostRevisionCreate {
+	return prc.SetPostID(p.ID)
+}
+
+// Mutation returns the PostRevisionMutation object of the builder.
+func (prc *PostRevisionCreate) Mutation() *PostRevisionMutation {
+	return prc.mutation
+}
+
+// Save creates the PostRevision in the database.
+func (prc *PostRevisionCreate) Save(ctx context.Context) (*PostRevision, error) {
+	prc.defaults()
+	return withHooks(ctx, prc.sqlSave, prc.mutation, prc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (prc *PostRevisionCreate) SaveX(ctx context.Context) *PostRevision {
+	v, err := prc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (prc *PostRevisionCreate) Exec(ctx context.Context) error {
+	_, err := prc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prc *PostRevisionCreate) ExecX(ctx context.Context) {
+	if err := prc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (prc *PostRevisionCreate) defaults() {
+	if _, ok := prc.mutation.ChangedBy(); !ok {
+		v := postrevision.DefaultChangedBy
+		prc.mutation.SetChangedBy(v)
+	}
+	if _, ok := prc.mutation.CreatedAt(); !ok {
+		v := postrevision.DefaultCreatedAt()
+		prc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := prc.mutation.ID(); !ok {
+		v := postrevision.DefaultID()
+		prc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (prc *PostRevisionCreate) check() error {
+	if _, ok := prc.mutation.PostID(); !ok {
+		return &ValidationError{Name: "post_id", err: errors.New(`ent: missing required field "PostRevision.post_id"`)}
+	}
+	if _, ok := prc.mutation.Revision(); !ok {
+		return &ValidationError{Name: "revision", err: errors.New(`ent: missing required field "PostRevision.revision"`)}
+	}
+	if _, ok := prc.mutation.Title(); !ok {
+		return &ValidationError{Name: "title", err: errors.New(`ent: missing required field "PostRevision.title"`)}
+	}
+	if _, ok := prc.mutation.Content(); !ok {
+		return &ValidationError{Name: "content", err: errors.New(`ent: missing required field "PostRevision.content"`)}
+	}
+	if _, ok := prc.mutation.Author(); !ok {
+		return &ValidationError{Name: "author", err: errors.New(`ent: missing required field "PostRevision.author"`)}
+	}
+	if _, ok := prc.mutation.PublishedOn(); !ok {
+		return &ValidationError{Name: "published_on", err: errors.New(`ent: missing required field "PostRevision.published_on"`)}
+	}
+	if _, ok := prc.mutation.Tags(); !ok {
+		return &ValidationError{Name: "tags", err: errors.New(`ent: missing required field "PostRevision.tags"`)}
+	}
+	if _, ok := prc.mutation.ChangedBy(); !ok {
+		return &ValidationError{Name: "changed_by", err: errors.New(`ent: missing required field "PostRevision.changed_by"`)}
+	}
+	if _, ok := prc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PostRevision.created_at"`)}
+	}
+	if _, ok := prc.mutation.PostID(); !ok {
+		return &ValidationError{Name: "post", err: errors.New(`ent: missing required edge "PostRevision.post"`)}
+	}
+	return nil
+}
+
+func (prc *PostRevisionCreate) sqlSave(ctx context.Context) (*PostRevision, error) {
+	if err := prc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := prc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, prc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	prc.mutation.id = &_node.ID
+	prc.mutation.done = true
+	return _node, nil
+}
+
+func (prc *PostRevisionCreate) createSpec() (*PostRevision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PostRevision{config: prc.config}
+		_spec = sqlgraph.NewCreateSpec(postrevision.Table, sqlgraph.NewFieldSpec(postrevision.FieldID, field.TypeUUID))
+	)
+	_spec.OnConflict = prc.conflict
+	if id, ok := prc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := prc.mutation.Revision(); ok {
+		_spec.SetField(postrevision.FieldRevision, field.TypeInt64, value)
+		_node.Revision = value
+	}
+	if value, ok := prc.mutation.Title(); ok {
+		_spec.SetField(postrevision.FieldTitle, field.TypeString, value)
+		_node.Title = value
+	}
+	if value, ok := prc.mutation.Content(); ok {
+		_spec.SetField(postrevision.FieldContent, field.TypeString, value)
+		_node.Content = value
+	}
+	if value, ok := prc.mutation.Author(); ok {
+		_spec.SetField(postrevision.FieldAuthor, field.TypeString, value)
+		_node.Author = value
+	}
+	if value, ok := prc.mutation.PublishedOn(); ok {
+		_spec.SetField(postrevision.FieldPublishedOn, field.TypeTime, value)
+		_node.PublishedOn = value
+	}
+	if value, ok := prc.mutation.Tags(); ok {
+		_spec.SetField(postrevision.FieldTags, field.TypeJSON, value)
+		_node.Tags = value
+	}
+	if value, ok := prc.mutation.ChangedBy(); ok {
+		_spec.SetField(postrevision.FieldChangedBy, field.TypeString, value)
+		_node.ChangedBy = value
+	}
+	if value, ok := prc.mutation.CreatedAt(); ok {
+		_spec.SetField(postrevision.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := prc.mutation.PostIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   postrevision.PostTable,
+			Columns: []string{postrevision.PostColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(post.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PostID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PostRevision.Create().
+//		SetPostID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PostRevisionUpsert) {
+//			SetPostID(v+v).
+//		}).
+//		Exec(ctx)
+func (prc *PostRevisionCreate) OnConflict(opts ...sql.ConflictOption) *PostRevisionUpsertOne {
+	prc.conflict = opts
+	return &PostRevisionUpsertOne{
+		create: prc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (prc *PostRevisionCreate) OnConflictColumns(columns ...string) *PostRevisionUpsertOne {
+	prc.conflict = append(prc.conflict, sql.ConflictColumns(columns...))
+	return &PostRevisionUpsertOne{
+		create: prc,
+	}
+}
+
+type (
+	// PostRevisionUpsertOne is the builder for "upsert"-ing
+	//  one PostRevision node.
+	PostRevisionUpsertOne struct {
+		create *PostRevisionCreate
+	}
+
+	// PostRevisionUpsert is the "OnConflict" setter.
+	PostRevisionUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(postrevision.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *PostRevisionUpsertOne) UpdateNewValues() *PostRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(postrevision.FieldID)
+		}
+		if _, exists := u.create.mutation.PostID(); exists {
+			s.SetIgnore(postrevision.FieldPostID)
+		}
+		if _, exists := u.create.mutation.Revision(); exists {
+			s.SetIgnore(postrevision.FieldRevision)
+		}
+		if _, exists := u.create.mutation.Title(); exists {
+			s.SetIgnore(postrevision.FieldTitle)
+		}
+		if _, exists := u.create.mutation.Content(); exists {
+			s.SetIgnore(postrevision.FieldContent)
+		}
+		if _, exists := u.create.mutation.Author(); exists {
+			s.SetIgnore(postrevision.FieldAuthor)
+		}
+		if _, exists := u.create.mutation.PublishedOn(); exists {
+			s.SetIgnore(postrevision.FieldPublishedOn)
+		}
+		if _, exists := u.create.mutation.Tags(); exists {
+			s.SetIgnore(postrevision.FieldTags)
+		}
+		if _, exists := u.create.mutation.ChangedBy(); exists {
+			s.SetIgnore(postrevision.FieldChangedBy)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(postrevision.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *PostRevisionUpsertOne) Ignore() *PostRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PostRevisionUpsertOne) DoNothing() *PostRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PostRevisionCreate.OnConflict
+// documentation for more info.
+func (u *PostRevisionUpsertOne) Update(set func(*PostRevisionUpsert)) *PostRevisionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PostRevisionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *PostRevisionUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PostRevisionCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PostRevisionUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *PostRevisionUpsertOne) ID(ctx context.Context) (id uuid.UUID, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: PostRevisionUpsertOne.ID is not supported by MySQL driver. Use PostRevisionUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *PostRevisionUpsertOne) IDX(ctx context.Context) uuid.UUID {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// PostRevisionCreateBulk is the builder for creating many PostRevision entities in bulk.
+type PostRevisionCreateBulk struct {
+	config
+	err      error
+	builders []*PostRevisionCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the PostRevision entities in the database.
+func (prcb *PostRevisionCreateBulk) Save(ctx context.Context) ([]*PostRevision, error) {
+	if prcb.err != nil {
+		return nil, prcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(prcb.builders))
+	nodes := make([]*PostRevision, len(prcb.builders))
+	mutators := make([]Mutator, len(prcb.builders))
+	for i := range prcb.builders {
+		func(i int, root context.Context) {
+			builder := prcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PostRevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, prcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = prcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, prcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, prcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (prcb *PostRevisionCreateBulk) SaveX(ctx context.Context) []*PostRevision {
+	v, err := prcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (prcb *PostRevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := prcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (prcb *PostRevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := prcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PostRevision.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PostRevisionUpsert) {
+//			SetPostID(v+v).
+//		}).
+//		Exec(ctx)
+func (prcb *PostRevisionCreateBulk) OnConflict(opts ...sql.ConflictOption) *PostRevisionUpsertBulk {
+	prcb.conflict = opts
+	return &PostRevisionUpsertBulk{
+		create: prcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (prcb *PostRevisionCreateBulk) OnConflictColumns(columns ...string) *PostRevisionUpsertBulk {
+	prcb.conflict = append(prcb.conflict, sql.ConflictColumns(columns...))
+	return &PostRevisionUpsertBulk{
+		create: prcb,
+	}
+}
+
+// PostRevisionUpsertBulk is the builder for "upsert"-ing
+// a bulk of PostRevision nodes.
+type PostRevisionUpsertBulk struct {
+	create *PostRevisionCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(postrevision.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *PostRevisionUpsertBulk) UpdateNewValues() *PostRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(postrevision.FieldID)
+			}
+			if _, exists := b.mutation.PostID(); exists {
+				s.SetIgnore(postrevision.FieldPostID)
+			}
+			if _, exists := b.mutation.Revision(); exists {
+				s.SetIgnore(postrevision.FieldRevision)
+			}
+			if _, exists := b.mutation.Title(); exists {
+				s.SetIgnore(postrevision.FieldTitle)
+			}
+			if _, exists := b.mutation.Content(); exists {
+				s.SetIgnore(postrevision.FieldContent)
+			}
+			if _, exists := b.mutation.Author(); exists {
+				s.SetIgnore(postrevision.FieldAuthor)
+			}
+			if _, exists := b.mutation.PublishedOn(); exists {
+				s.SetIgnore(postrevision.FieldPublishedOn)
+			}
+			if _, exists := b.mutation.Tags(); exists {
+				s.SetIgnore(postrevision.FieldTags)
+			}
+			if _, exists := b.mutation.ChangedBy(); exists {
+				s.SetIgnore(postrevision.FieldChangedBy)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(postrevision.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PostRevision.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *PostRevisionUpsertBulk) Ignore() *PostRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PostRevisionUpsertBulk) DoNothing() *PostRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PostRevisionCreateBulk.OnConflict
+// documentation for more info.
+func (u *PostRevisionUpsertBulk) Update(set func(*PostRevisionUpsert)) *PostRevisionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PostRevisionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *PostRevisionUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the PostRevisionCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PostRevisionCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PostRevisionUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
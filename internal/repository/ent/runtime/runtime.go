@@ -2,7 +2,87 @@
 
 package runtime
 
-// The schema-stitching logic is generated in github.com/sdoshi579/cloudbees/internal/repository/ent/runtime.go
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/apikey"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/schema"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	apikeyFields := schema.ApiKey{}.Fields()
+	_ = apikeyFields
+	// apikeyDescName is the schema descriptor for name field.
+	apikeyDescName := apikeyFields[1].Descriptor()
+	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apikey.NameValidator = apikeyDescName.Validators[0].(func(string) error)
+	// apikeyDescCreatedBy is the schema descriptor for created_by field.
+	apikeyDescCreatedBy := apikeyFields[5].Descriptor()
+	// apikey.DefaultCreatedBy holds the default value on creation for the created_by field.
+	apikey.DefaultCreatedBy = apikeyDescCreatedBy.Default.(string)
+	// apikeyDescCreatedAt is the schema descriptor for created_at field.
+	apikeyDescCreatedAt := apikeyFields[8].Descriptor()
+	// apikey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apikey.DefaultCreatedAt = apikeyDescCreatedAt.Default.(func() time.Time)
+	// apikeyDescID is the schema descriptor for id field.
+	apikeyDescID := apikeyFields[0].Descriptor()
+	// apikey.DefaultID holds the default value on creation for the id field.
+	apikey.DefaultID = apikeyDescID.Default.(func() uuid.UUID)
+	postHooks := schema.Post{}.Hooks()
+	post.Hooks[0] = postHooks[0]
+	postFields := schema.Post{}.Fields()
+	_ = postFields
+	// postDescOwner is the schema descriptor for owner field.
+	postDescOwner := postFields[4].Descriptor()
+	// post.DefaultOwner holds the default value on creation for the owner field.
+	post.DefaultOwner = postDescOwner.Default.(string)
+	// postDescIsDeleted is the schema descriptor for is_deleted field.
+	postDescIsDeleted := postFields[7].Descriptor()
+	// post.DefaultIsDeleted holds the default value on creation for the is_deleted field.
+	post.DefaultIsDeleted = postDescIsDeleted.Default.(bool)
+	// postDescRevision is the schema descriptor for revision field.
+	postDescRevision := postFields[10].Descriptor()
+	// post.DefaultRevision holds the default value on creation for the revision field.
+	post.DefaultRevision = postDescRevision.Default.(int64)
+	// postDescChangedBy is the schema descriptor for changed_by field.
+	postDescChangedBy := postFields[11].Descriptor()
+	// post.DefaultChangedBy holds the default value on creation for the changed_by field.
+	post.DefaultChangedBy = postDescChangedBy.Default.(string)
+	// postDescCreatedAt is the schema descriptor for created_at field.
+	postDescCreatedAt := postFields[12].Descriptor()
+	// post.DefaultCreatedAt holds the default value on creation for the created_at field.
+	post.DefaultCreatedAt = postDescCreatedAt.Default.(func() time.Time)
+	// postDescUpdatedAt is the schema descriptor for updated_at field.
+	postDescUpdatedAt := postFields[13].Descriptor()
+	// post.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	post.DefaultUpdatedAt = postDescUpdatedAt.Default.(func() time.Time)
+	// post.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	post.UpdateDefaultUpdatedAt = postDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// postDescID is the schema descriptor for id field.
+	postDescID := postFields[0].Descriptor()
+	// post.DefaultID holds the default value on creation for the id field.
+	post.DefaultID = postDescID.Default.(func() uuid.UUID)
+	postrevisionFields := schema.PostRevision{}.Fields()
+	_ = postrevisionFields
+	// postrevisionDescChangedBy is the schema descriptor for changed_by field.
+	postrevisionDescChangedBy := postrevisionFields[8].Descriptor()
+	// postrevision.DefaultChangedBy holds the default value on creation for the changed_by field.
+	postrevision.DefaultChangedBy = postrevisionDescChangedBy.Default.(string)
+	// postrevisionDescCreatedAt is the schema descriptor for created_at field.
+	postrevisionDescCreatedAt := postrevisionFields[9].Descriptor()
+	// postrevision.DefaultCreatedAt holds the default value on creation for the created_at field.
+	postrevision.DefaultCreatedAt = postrevisionDescCreatedAt.Default.(func() time.Time)
+	// postrevisionDescID is the schema descriptor for id field.
+	postrevisionDescID := postrevisionFields[0].Descriptor()
+	// postrevision.DefaultID holds the default value on creation for the id field.
+	postrevision.DefaultID = postrevisionDescID.Default.(func() uuid.UUID)
+}
 
 const (
 	Version = "v0.13.1"                                         // Version of ent codegen.
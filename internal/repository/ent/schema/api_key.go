@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ApiKey holds the schema definition for the ApiKey entity, a credential
+// for callers that cannot obtain a JWT. Only the SHA-256 hash of the key is
+// stored.
+type ApiKey struct {
+	ent.Schema
+}
+
+// Fields of the ApiKey.
+func (ApiKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.String("name").NotEmpty().Unique().Immutable(),
+		field.String("key_hash").Unique().Immutable().Sensitive(),
+		// key_prefix is the start of the key, enough to tell keys apart in listings.
+		field.String("key_prefix").Immutable(),
+		// scopes are the roles granted to callers presenting the key.
+		field.Strings("scopes").Immutable(),
+		field.String("created_by").Default("").Immutable(),
+		field.Time("expires_at").Optional().Nillable().Immutable(),
+		field.Time("revoked_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
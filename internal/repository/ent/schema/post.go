@@ -1,9 +1,16 @@
 package schema
 
 import (
+	"context"
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	gen "github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/hook"
 	"time"
 )
 
@@ -19,9 +26,19 @@ func (Post) Fields() []ent.Field {
 		field.String("title"),
 		field.Text("content"),
 		field.String("author"),
+		// owner is the subject of the principal that created the post, empty
+		// for posts created without authentication.
+		field.String("owner").Default("").Immutable(),
 		field.Time("published_on"),
 		field.Strings("tags"),
 		field.Bool("is_deleted").Default(false),
+		field.Time("deleted_at").Optional().Nillable(),
+		field.String("deleted_by").Optional().Nillable(),
+		// revision is bumped on every write and used for optimistic concurrency control.
+		field.Int64("revision").Default(1),
+		// changed_by identifies who made the latest revision and is copied to
+		// its snapshot, it is empty when unknown.
+		field.String("changed_by").Default(""),
 		field.Time("created_at").Default(time.Now),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -29,5 +46,55 @@ func (Post) Fields() []ent.Field {
 
 // Edges of the Post.
 func (Post) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		edge.To("revisions", PostRevision.Type).Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Hooks of the Post.
+func (Post) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(snapshotRevision, ent.OpCreate|ent.OpUpdateOne),
+	}
+}
+
+// ErrRevisionExists is returned by post writes whose revision was already
+// recorded by a concurrent write to the same post.
+var ErrRevisionExists = errors.New("post revision already exists")
+
+// snapshotRevision bumps the revision of a post on every update and records
+// the saved state as a PostRevision. Callers should run post writes in a
+// transaction so the post and its revision are committed together.
+func snapshotRevision(next ent.Mutator) ent.Mutator {
+	return hook.PostFunc(func(ctx context.Context, m *gen.PostMutation) (ent.Value, error) {
+		if m.Op().Is(ent.OpUpdateOne) {
+			m.AddRevision(1)
+		}
+		value, err := next.Mutate(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		saved, ok := value.(*gen.Post)
+		if !ok {
+			return nil, fmt.Errorf("unexpected post mutation result %T", value)
+		}
+		err = m.Client().PostRevision.Create().
+			SetPostID(saved.ID).
+			SetRevision(saved.Revision).
+			SetTitle(saved.Title).
+			SetContent(saved.Content).
+			SetAuthor(saved.Author).
+			SetPublishedOn(saved.PublishedOn).
+			SetTags(saved.Tags).
+			SetChangedBy(saved.ChangedBy).
+			Exec(ctx)
+		if gen.IsConstraintError(err) {
+			// the unique (post_id, revision) index is the only one on revisions
+			return nil, fmt.Errorf("saving post revision %d: %w: %w", saved.Revision, ErrRevisionExists, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("saving post revision: %w", err)
+		}
+		return value, nil
+	})
 }
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PostRevision holds the schema definition for the PostRevision entity, a
+// snapshot of a post taken after every write.
+type PostRevision struct {
+	ent.Schema
+}
+
+// Fields of the PostRevision.
+func (PostRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("post_id", uuid.UUID{}).Immutable(),
+		field.Int64("revision").Immutable(),
+		field.String("title").Immutable(),
+		field.Text("content").Immutable(),
+		field.String("author").Immutable(),
+		field.Time("published_on").Immutable(),
+		field.Strings("tags").Immutable(),
+		field.String("changed_by").Default("").Immutable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the PostRevision.
+func (PostRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("post", Post.Type).Ref("revisions").Field("post_id").Unique().Required().Immutable(),
+	}
+}
+
+// Indexes of the PostRevision.
+func (PostRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("post_id", "revision").Unique(),
+	}
+}
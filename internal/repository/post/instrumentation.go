@@ -0,0 +1,50 @@
+package post
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// WithMetrics records the duration of every repository operation in the
+// cloudbees_repository_query_duration_seconds histogram of registerer.
+func WithMetrics(registerer prometheus.Registerer) RepoConfiguration {
+	return func(r *repositoryImplementation) {
+		r.queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   "cloudbees",
+			Subsystem:   "repository",
+			Name:        "query_duration_seconds",
+			Help:        "Duration of post repository operations, including their transaction.",
+			ConstLabels: prometheus.Labels{"repository": resourcePost},
+			Buckets:     prometheus.DefBuckets,
+		}, []string{"operation"})
+		registerer.MustRegister(r.queryDuration)
+	}
+}
+
+// WithTracerProvider records every repository operation as a span.
+func WithTracerProvider(provider trace.TracerProvider) RepoConfiguration {
+	return func(r *repositoryImplementation) {
+		r.tracer = provider.Tracer("github.com/sdoshi579/cloudbees/internal/repository/post")
+	}
+}
+
+// instrument starts an operation, it is called at the top of every
+// operation with the returned function deferred. The function ends the span
+// of the operation and records its duration.
+func (r *repositoryImplementation) instrument(ctx context.Context, operation string) (context.Context, func()) {
+	start := time.Now()
+	var span trace.Span
+	if r.tracer != nil {
+		ctx, span = r.tracer.Start(ctx, "repository.post."+operation)
+	}
+	return ctx, func() {
+		if span != nil {
+			span.End()
+		}
+		if r.queryDuration != nil {
+			r.queryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package post
+
+import (
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/predicate"
+)
+
+// filterPredicates translates a listing filter into ent predicates.
+func filterPredicates(filter entity.PostFilter) []predicate.Post {
+	var predicates []predicate.Post
+
+	if filter.Author != "" {
+		predicates = append(predicates, post.AuthorEQ(filter.Author))
+	}
+	if len(filter.Tags) != 0 {
+		tagPredicates := make([]predicate.Post, 0, len(filter.Tags))
+		for _, tag := range filter.Tags {
+			tagPredicates = append(tagPredicates, hasTag(tag))
+		}
+		if filter.TagMatch == entity.TagMatchAll {
+			predicates = append(predicates, post.And(tagPredicates...))
+		} else {
+			predicates = append(predicates, post.Or(tagPredicates...))
+		}
+	}
+	if filter.PublishedAfter != nil {
+		predicates = append(predicates, post.PublishedOnGTE(*filter.PublishedAfter))
+	}
+	if filter.PublishedBefore != nil {
+		predicates = append(predicates, post.PublishedOnLT(*filter.PublishedBefore))
+	}
+	return predicates
+}
+
+// hasTag matches posts whose tags JSON array contains the given tag.
+func hasTag(tag string) predicate.Post {
+	return predicate.Post(func(s *sql.Selector) {
+		if s.Dialect() != dialect.SQLite {
+			s.Where(sqljson.ValueContains(s.C(post.FieldTags), tag))
+			return
+		}
+		// tags are stored as a blob, which SQLite >= 3.45 would read as JSONB,
+		// so the column is cast to text before it is expanded.
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.WriteString("EXISTS (SELECT 1 FROM JSON_EACH(CAST(").Ident(s.C(post.FieldTags)).
+				WriteString(" AS TEXT)) WHERE value = ").Arg(tag).WriteString(")")
+		}))
+	})
+}
+
+func sortOrder(sort entity.PostSort) []post.OrderOption {
+	direction := sql.OrderAsc()
+	if sort.Descending {
+		direction = sql.OrderDesc()
+	}
+
+	var field post.OrderOption
+	switch sort.Field {
+	case entity.PostSortByPublishedOn:
+		field = post.ByPublishedOn(direction)
+	case entity.PostSortByTitle:
+		field = post.ByTitle(direction)
+	default:
+		field = post.ByCreatedAt(direction)
+	}
+	// id breaks ties between equal sort keys so the keyset is total
+	return []post.OrderOption{field, post.ByID(direction)}
+}
+
+// afterToken matches posts that come strictly after the token in the given sort order.
+func afterToken(token *pageToken) predicate.Post {
+	var after, equal predicate.Post
+	switch token.SortField {
+	case entity.PostSortByPublishedOn:
+		after, equal = post.PublishedOnGT(token.Time), post.PublishedOn(token.Time)
+		if token.Descending {
+			after = post.PublishedOnLT(token.Time)
+		}
+	case entity.PostSortByTitle:
+		after, equal = post.TitleGT(token.Title), post.Title(token.Title)
+		if token.Descending {
+			after = post.TitleLT(token.Title)
+		}
+	default:
+		after, equal = post.CreatedAtGT(token.Time), post.CreatedAt(token.Time)
+		if token.Descending {
+			after = post.CreatedAtLT(token.Time)
+		}
+	}
+
+	idAfter := post.IDGT(token.ID)
+	if token.Descending {
+		idAfter = post.IDLT(token.ID)
+	}
+	return post.Or(after, post.And(equal, idAfter))
+}
@@ -0,0 +1,92 @@
+package post
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"time"
+)
+
+var errInvalidPageToken = domainerror.InvalidArgument("invalid page token", domainerror.FieldViolation{
+	Field:       "page_token",
+	Description: "page token is malformed or was issued for a different sort order",
+})
+
+var errInvalidRevisionPageToken = domainerror.InvalidArgument("invalid page token", domainerror.FieldViolation{
+	Field:       "page_token",
+	Description: "page token is malformed or was issued for a different post",
+})
+
+// pageToken is the keyset cursor handed out to clients as an opaque string.
+// It points at the last post of the previous page and remembers the sort it
+// was issued for, so it cannot be replayed against a different ordering.
+type pageToken struct {
+	SortField  entity.PostSortField `json:"f"`
+	Descending bool                 `json:"d"`
+	Time       time.Time            `json:"t,omitempty"`
+	Title      string               `json:"s,omitempty"`
+	ID         uuid.UUID            `json:"i"`
+}
+
+func newPageToken(sort entity.PostSort, last *ent.Post) pageToken {
+	token := pageToken{SortField: sort.Field, Descending: sort.Descending, ID: last.ID}
+	switch sort.Field {
+	case entity.PostSortByPublishedOn:
+		token.Time = last.PublishedOn
+	case entity.PostSortByTitle:
+		token.Title = last.Title
+	default:
+		token.Time = last.CreatedAt
+	}
+	return token
+}
+
+func (p pageToken) encode() string {
+	return encodeToken(p)
+}
+
+func decodePageToken(token string, sort entity.PostSort) (*pageToken, error) {
+	var p pageToken
+	if err := decodeToken(token, &p); err != nil || p.ID == uuid.Nil {
+		return nil, errInvalidPageToken
+	}
+	if p.SortField != sort.Field || p.Descending != sort.Descending {
+		return nil, errInvalidPageToken
+	}
+	return &p, nil
+}
+
+// revisionPageToken points at the last revision of the previous page of
+// revisions of a post, which are listed newest first.
+type revisionPageToken struct {
+	PostID   uuid.UUID `json:"p"`
+	Revision int64     `json:"r"`
+}
+
+func (p revisionPageToken) encode() string {
+	return encodeToken(p)
+}
+
+func decodeRevisionPageToken(token string, postID uuid.UUID) (*revisionPageToken, error) {
+	var p revisionPageToken
+	if err := decodeToken(token, &p); err != nil || p.PostID != postID || p.Revision <= 0 {
+		return nil, errInvalidRevisionPageToken
+	}
+	return &p, nil
+}
+
+func encodeToken(v any) string {
+	raw, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeToken(token string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
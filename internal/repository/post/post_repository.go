@@ -2,24 +2,45 @@ package post
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
 	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/logging"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent"
 	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/schema"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"time"
 )
 
 //go:generate mockgen -destination=../../mockgen/repository/post/post_repository.go -source=./post_repository.go Repository
 type Repository interface {
 	CreatePost(ctx context.Context, request entity.CreatePostRequest) (*entity.PostDetail, error)
 	GetPost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error)
+	GetPostOwner(ctx context.Context, id uuid.UUID) (string, error)
 	UpdatePost(ctx context.Context, id uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error)
-	DeletePost(ctx context.Context, id uuid.UUID) (bool, error)
+	DeletePost(ctx context.Context, id uuid.UUID, request entity.DeletePostRequest) error
+	ListPosts(ctx context.Context, request entity.ListPostsRequest) (*entity.ListPostsResponse, error)
+	RestorePost(ctx context.Context, id uuid.UUID, restoredBy string) (*entity.PostDetail, error)
+	PurgePost(ctx context.Context, id uuid.UUID) error
+	PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error)
+	ListRevisions(ctx context.Context, postID uuid.UUID,
+		request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, postID uuid.UUID, revision int64) (*entity.PostRevision, error)
+	CountPosts(ctx context.Context) (*entity.PostCounts, error)
 }
 
+const resourcePost = "post"
+
 type repositoryImplementation struct {
-	entClient *ent.Client
-	logger    *zap.Logger
+	entClient     *ent.Client
+	logger        *zap.Logger
+	queryDuration *prometheus.HistogramVec
+	tracer        trace.Tracer
 }
 
 type RepoConfiguration func(r *repositoryImplementation)
@@ -38,6 +59,12 @@ func WithLogger(logger *zap.Logger) RepoConfiguration {
 	}
 }
 
+// log returns the logger of the call ctx belongs to, or the repository logger
+// when there is none, e.g. for the retention job.
+func (r *repositoryImplementation) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, r.logger)
+}
+
 func WithEntClient(client *ent.Client) RepoConfiguration {
 	return func(r *repositoryImplementation) {
 		r.entClient = client
@@ -46,13 +73,23 @@ func WithEntClient(client *ent.Client) RepoConfiguration {
 
 func (r *repositoryImplementation) CreatePost(ctx context.Context,
 	request entity.CreatePostRequest) (*entity.PostDetail, error) {
+	ctx, done := r.instrument(ctx, "create_post")
+	defer done()
 
-	resp, err := r.entClient.Post.Create().SetTitle(request.Title).SetContent(request.Content).
-		SetAuthor(request.Author).
-		SetPublishedOn(request.PublishedOn).SetTags(request.Tags).Save(ctx)
+	var resp *ent.Post
+	err := r.withTx(ctx, func(client *ent.Client) (err error) {
+		resp, err = client.Post.Create().SetTitle(request.Title).SetContent(request.Content).
+			SetAuthor(request.Author).SetOwner(request.Owner).SetChangedBy(request.Owner).
+			SetPublishedOn(request.PublishedOn).SetTags(request.Tags).Save(ctx)
+		return err
+	})
 
 	if err != nil {
-		r.logger.Error("error in saving post", zap.Error(err), zap.Any("request", request))
+		err = toDomainError(err, uuid.Nil)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in saving post", zap.Error(err), logging.String("title", request.Title),
+				zap.String("owner", request.Owner))
+		}
 		return nil, err
 	}
 
@@ -60,50 +97,251 @@ func (r *repositoryImplementation) CreatePost(ctx context.Context,
 }
 
 func (r *repositoryImplementation) GetPost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error) {
+	ctx, done := r.instrument(ctx, "get_post")
+	defer done()
 	resp, err := r.entClient.Post.Query().Where(post.ID(id), post.IsDeleted(false)).Only(ctx)
 
 	if err != nil {
-		r.logger.Error("error in fetching post", zap.Error(err), zap.Any("postID", id))
-		return nil, err
+		if !ent.IsNotFound(err) {
+			r.log(ctx).Error("error in fetching post", zap.Error(err), zap.Any("postID", id))
+		}
+		return nil, toDomainError(err, id)
 	}
 	return decoratePostEntity(*resp), nil
 }
 
+// GetPostOwner returns the owner of a post, soft-deleted posts included.
+func (r *repositoryImplementation) GetPostOwner(ctx context.Context, id uuid.UUID) (string, error) {
+	ctx, done := r.instrument(ctx, "get_post_owner")
+	defer done()
+	owner, err := r.entClient.Post.Query().Where(post.ID(id)).Select(post.FieldOwner).String(ctx)
+
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			r.log(ctx).Error("error in fetching post owner", zap.Error(err), zap.Any("postID", id))
+		}
+		return "", toDomainError(err, id)
+	}
+	return owner, nil
+}
+
 func (r *repositoryImplementation) UpdatePost(ctx context.Context, id uuid.UUID,
 	request entity.UpdatePostRequest) (*entity.PostDetail, error) {
-	query := r.entClient.Post.UpdateOneID(id)
+	ctx, done := r.instrument(ctx, "update_post")
+	defer done()
+	var resp *ent.Post
+	err := r.withTx(ctx, func(client *ent.Client) (err error) {
+		query, err := updatePostQuery(client, id, request)
+		if err != nil {
+			return err
+		}
+		resp, err = query.Save(ctx)
+		return err
+	})
+	if err != nil {
+		err = r.checkRevision(ctx, id, request.ExpectedRevision, err)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in updating post", zap.Error(err), zap.Any("postID", id),
+				zap.Strings("updateMask", request.UpdateMask), zap.Int64("expectedRevision", request.ExpectedRevision))
+		}
+		return nil, err
+	}
+
+	return decoratePostEntity(*resp), nil
+}
 
-	if request.Title != nil {
-		query.SetTitle(*request.Title)
+// updatePostQuery builds an update writing exactly the masked fields.
+func updatePostQuery(client *ent.Client, id uuid.UUID,
+	request entity.UpdatePostRequest) (*ent.PostUpdateOne, error) {
+	query := client.Post.UpdateOneID(id).Where(post.IsDeleted(false)).SetChangedBy(request.ChangedBy)
+	if request.ExpectedRevision != 0 {
+		query.Where(post.Revision(request.ExpectedRevision))
 	}
-	if request.Content != nil {
-		query.SetContent(*request.Content)
+
+	for _, path := range request.UpdateMask {
+		switch path {
+		case entity.PostFieldTitle:
+			query.SetNillableTitle(request.Title)
+		case entity.PostFieldContent:
+			query.SetNillableContent(request.Content)
+		case entity.PostFieldAuthor:
+			query.SetNillableAuthor(request.Author)
+		case entity.PostFieldPublishedOn:
+			query.SetNillablePublishedOn(request.PublishedOn)
+		case entity.PostFieldTags:
+			if len(request.Tags) == 0 {
+				// tags is a non-null JSON column, clearing stores an empty list
+				query.SetTags([]string{})
+			} else {
+				query.SetTags(request.Tags)
+			}
+		default:
+			return nil, domainerror.InvalidArgument("invalid update mask", domainerror.FieldViolation{
+				Field:       "update_mask",
+				Description: fmt.Sprintf("unknown path %q", path),
+			})
+		}
 	}
-	if request.Author != nil {
-		query.SetAuthor(*request.Author)
+	return query, nil
+}
+
+// DeletePost soft-deletes a post. Deleting an already deleted post succeeds
+// and keeps the original deletion, deleting a missing post only succeeds
+// with AllowMissing.
+func (r *repositoryImplementation) DeletePost(ctx context.Context, id uuid.UUID,
+	request entity.DeletePostRequest) error {
+	ctx, done := r.instrument(ctx, "delete_post")
+	defer done()
+	err := r.withTx(ctx, func(client *ent.Client) error {
+		current, err := client.Post.Get(ctx, id)
+		if err != nil {
+			if ent.IsNotFound(err) && request.AllowMissing {
+				return nil
+			}
+			return err
+		}
+		if request.ExpectedRevision != 0 && current.Revision != request.ExpectedRevision {
+			return revisionConflict(id, request.ExpectedRevision)
+		}
+		if current.IsDeleted {
+			return nil
+		}
+
+		query := client.Post.UpdateOneID(id).Where(post.Revision(current.Revision)).
+			SetIsDeleted(true).SetDeletedAt(time.Now()).SetChangedBy(request.DeletedBy)
+		if request.DeletedBy != "" {
+			query.SetDeletedBy(request.DeletedBy)
+		}
+		if err := query.Exec(ctx); ent.IsNotFound(err) {
+			// the post changed after it was read
+			return revisionConflict(id, current.Revision)
+		} else if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		err = toDomainError(err, id)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in deleting post", zap.Error(err), zap.Any("postID", id))
+		}
+		return err
 	}
-	if len(request.Tags) != 0 {
-		query.SetTags(request.Tags)
+	return nil
+}
+
+// checkRevision tells a revision mismatch apart from a missing post after a
+// conditional write matched no row.
+func (r *repositoryImplementation) checkRevision(ctx context.Context, id uuid.UUID, expected int64,
+	err error) error {
+	if expected == 0 || !ent.IsNotFound(err) {
+		return toDomainError(err, id)
 	}
+	exists, existErr := r.entClient.Post.Query().Where(post.ID(id), post.IsDeleted(false)).Exist(ctx)
+	if existErr != nil || !exists {
+		return toDomainError(err, id)
+	}
+	return revisionConflict(id, expected)
+}
+
+func revisionConflict(id uuid.UUID, expected int64) error {
+	return domainerror.Conflict(resourcePost, id.String(),
+		fmt.Sprintf("post was modified, expected revision %d is stale", expected))
+}
+
+func (r *repositoryImplementation) ListPosts(ctx context.Context,
+	request entity.ListPostsRequest) (*entity.ListPostsResponse, error) {
+	ctx, done := r.instrument(ctx, "list_posts")
+	defer done()
+	query := r.entClient.Post.Query().Where(post.IsDeleted(false)).Where(filterPredicates(request.Filter)...)
 
-	resp, err := query.Save(ctx)
+	if request.PageToken != "" {
+		token, err := decodePageToken(request.PageToken, request.Sort)
+		if err != nil {
+			return nil, err
+		}
+		query.Where(afterToken(token))
+	}
+
+	// fetch one extra row to find out whether another page exists
+	resp, err := query.Order(sortOrder(request.Sort)...).Limit(request.PageSize + 1).All(ctx)
 	if err != nil {
-		r.logger.Error("error in updating post", zap.Error(err), zap.Any("request", request),
-			zap.Any("postID", id))
+		r.log(ctx).Error("error in listing posts", zap.Error(err), zap.Int("pageSize", request.PageSize),
+			zap.Int("sortField", int(request.Sort.Field)), zap.Bool("sortDescending", request.Sort.Descending),
+			logging.String("author", request.Filter.Author))
 		return nil, err
 	}
 
-	return decoratePostEntity(*resp), nil
+	result := &entity.ListPostsResponse{}
+	if len(resp) > request.PageSize {
+		resp = resp[:request.PageSize]
+		result.NextPageToken = newPageToken(request.Sort, resp[len(resp)-1]).encode()
+	}
+	result.Posts = make([]*entity.PostDetail, 0, len(resp))
+	for _, p := range resp {
+		result.Posts = append(result.Posts, decoratePostEntity(*p))
+	}
+	return result, nil
 }
 
-func (r *repositoryImplementation) DeletePost(ctx context.Context, id uuid.UUID) (bool, error) {
-	err := r.entClient.Post.UpdateOneID(id).SetIsDeleted(true).Exec(ctx)
+// CountPosts counts the live and the soft-deleted posts.
+func (r *repositoryImplementation) CountPosts(ctx context.Context) (*entity.PostCounts, error) {
+	ctx, done := r.instrument(ctx, "count_posts")
+	defer done()
+	var groups []struct {
+		IsDeleted bool `json:"is_deleted"`
+		Count     int  `json:"count"`
+	}
+	err := r.entClient.Post.Query().GroupBy(post.FieldIsDeleted).Aggregate(ent.Count()).Scan(ctx, &groups)
+	if err != nil {
+		r.log(ctx).Error("error in counting posts", zap.Error(err))
+		return nil, err
+	}
+
+	counts := &entity.PostCounts{}
+	for _, group := range groups {
+		if group.IsDeleted {
+			counts.Deleted = group.Count
+		} else {
+			counts.Live = group.Count
+		}
+	}
+	return counts, nil
+}
 
+// withTx runs fn in a transaction, post writes need one so that the revision
+// snapshot taken by the schema hook is committed together with the post.
+func (r *repositoryImplementation) withTx(ctx context.Context, fn func(client *ent.Client) error) error {
+	tx, err := r.entClient.Tx(ctx)
 	if err != nil {
-		r.logger.Error("error in deleting post", zap.Error(err), zap.Any("postID", id))
-		return false, err
+		return err
+	}
+	if err := fn(tx.Client()); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			r.log(ctx).Error("error in rolling back transaction", zap.Error(rollbackErr))
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// toDomainError maps ent errors onto domain errors, anything unexpected is
+// returned untouched. Post IDs are generated by the server so no constraint
+// error means the post already exists, the only expected one is a revision
+// recorded concurrently.
+func toDomainError(err error, id uuid.UUID) error {
+	var domainErr *domainerror.Error
+	switch {
+	case errors.As(err, &domainErr):
+		return err
+	case ent.IsNotFound(err):
+		return domainerror.NotFound(resourcePost, id.String()).Wrap(err)
+	case errors.Is(err, schema.ErrRevisionExists):
+		return domainerror.Conflict(resourcePost, id.String(), "post was modified concurrently").Wrap(err)
+	case ent.IsValidationError(err):
+		return domainerror.InvalidArgument(err.Error()).Wrap(err)
 	}
-	return true, nil
+	return err
 }
 
 func decoratePostEntity(postEnt ent.Post) *entity.PostDetail {
@@ -114,5 +352,8 @@ func decoratePostEntity(postEnt ent.Post) *entity.PostDetail {
 		Title:       postEnt.Title,
 		Content:     postEnt.Content,
 		PublishedOn: postEnt.PublishedOn,
+		CreatedAt:   postEnt.CreatedAt,
+		Revision:    postEnt.Revision,
+		Owner:       postEnt.Owner,
 	}
 }
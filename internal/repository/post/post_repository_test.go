@@ -0,0 +1,665 @@
+package post
+
+import (
+	"context"
+	"entgo.io/ent/dialect"
+	"fmt"
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/enttest"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestRepository(t *testing.T) *repositoryImplementation {
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	return &repositoryImplementation{entClient: client, logger: zap.NewNop()}
+}
+
+func Test_repositoryImplementation_ListPosts(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	createdAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	var want []string
+	for i, title := range []string{"first", "second", "third", "fourth", "fifth"} {
+		// two posts share a timestamp so the id tie-breaker is exercised
+		p := r.entClient.Post.Create().SetTitle(title).SetContent("content").SetAuthor("author").
+			SetPublishedOn(createdAt).SetTags([]string{}).SetCreatedAt(createdAt.Add(time.Duration(i/2) * time.Hour)).
+			SaveX(ctx)
+		want = append(want, p.ID.String())
+	}
+	r.entClient.Post.Create().SetTitle("deleted").SetContent("content").SetAuthor("author").
+		SetPublishedOn(createdAt).SetTags([]string{}).SetIsDeleted(true).SaveX(ctx)
+
+	var got []string
+	token := ""
+	pages := 0
+	for {
+		resp, err := r.ListPosts(ctx, entity.ListPostsRequest{PageSize: 2, PageToken: token})
+		if err != nil {
+			t.Fatalf("ListPosts() error = %v", err)
+		}
+		pages++
+		for _, p := range resp.Posts {
+			got = append(got, p.ID.String())
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		token = resp.NextPageToken
+	}
+
+	if pages != 3 {
+		t.Errorf("ListPosts() pages = %d, want 3", pages)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListPosts() got %d posts, want %d", len(got), len(want))
+	}
+	seen := map[string]bool{}
+	for _, id := range got {
+		if seen[id] {
+			t.Errorf("ListPosts() returned post %s twice", id)
+		}
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			t.Errorf("ListPosts() did not return post %s", id)
+		}
+	}
+}
+
+func Test_repositoryImplementation_ListPosts_invalidToken(t *testing.T) {
+	r := newTestRepository(t)
+
+	_, err := r.ListPosts(context.Background(), entity.ListPostsRequest{PageSize: 2, PageToken: "not-a-token"})
+	if err != errInvalidPageToken {
+		t.Errorf("ListPosts() error = %v, want %v", err, errInvalidPageToken)
+	}
+}
+
+func Test_repositoryImplementation_ListPosts_filterAndSort(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	posts := []struct {
+		title  string
+		author string
+		tags   []string
+		day    int
+	}{
+		{title: "alpha", author: "ann", tags: []string{"go", "grpc"}, day: 0},
+		{title: "bravo", author: "bob", tags: []string{"go"}, day: 1},
+		{title: "charlie", author: "ann", tags: []string{"rust"}, day: 2},
+		{title: "delta", author: "ann", tags: []string{"grpc"}, day: 3},
+	}
+	for _, p := range posts {
+		r.entClient.Post.Create().SetTitle(p.title).SetContent("content").SetAuthor(p.author).
+			SetPublishedOn(day.AddDate(0, 0, p.day)).SetTags(p.tags).SaveX(ctx)
+	}
+
+	after, before := day.AddDate(0, 0, 1), day.AddDate(0, 0, 3)
+	tests := []struct {
+		name    string
+		request entity.ListPostsRequest
+		want    []string
+	}{
+		{
+			name:    "by author",
+			request: entity.ListPostsRequest{Filter: entity.PostFilter{Author: "ann"}},
+			want:    []string{"alpha", "charlie", "delta"},
+		},
+		{
+			name:    "any tag",
+			request: entity.ListPostsRequest{Filter: entity.PostFilter{Tags: []string{"rust", "grpc"}}},
+			want:    []string{"alpha", "charlie", "delta"},
+		},
+		{
+			name: "all tags",
+			request: entity.ListPostsRequest{Filter: entity.PostFilter{Tags: []string{"go", "grpc"},
+				TagMatch: entity.TagMatchAll}},
+			want: []string{"alpha"},
+		},
+		{
+			name: "publish window",
+			request: entity.ListPostsRequest{Filter: entity.PostFilter{PublishedAfter: &after,
+				PublishedBefore: &before}},
+			want: []string{"bravo", "charlie"},
+		},
+		{
+			name:    "title descending",
+			request: entity.ListPostsRequest{Sort: entity.PostSort{Field: entity.PostSortByTitle, Descending: true}},
+			want:    []string{"delta", "charlie", "bravo", "alpha"},
+		},
+		{
+			name: "published on descending with author",
+			request: entity.ListPostsRequest{Filter: entity.PostFilter{Author: "ann"},
+				Sort: entity.PostSort{Field: entity.PostSortByPublishedOn, Descending: true}},
+			want: []string{"delta", "charlie", "alpha"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			request := tt.request
+			request.PageSize = 1
+			for {
+				resp, err := r.ListPosts(ctx, request)
+				if err != nil {
+					t.Fatalf("ListPosts() error = %v", err)
+				}
+				for _, p := range resp.Posts {
+					got = append(got, p.Title)
+				}
+				if resp.NextPageToken == "" {
+					break
+				}
+				request.PageToken = resp.NextPageToken
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListPosts() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repositoryImplementation_ListPosts_tokenSortMismatch(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	for _, title := range []string{"first", "second"} {
+		r.entClient.Post.Create().SetTitle(title).SetContent("content").SetAuthor("author").
+			SetPublishedOn(time.Now()).SetTags([]string{}).SaveX(ctx)
+	}
+	resp, err := r.ListPosts(ctx, entity.ListPostsRequest{PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListPosts() error = %v", err)
+	}
+
+	_, err = r.ListPosts(ctx, entity.ListPostsRequest{PageSize: 1, PageToken: resp.NextPageToken,
+		Sort: entity.PostSort{Field: entity.PostSortByTitle}})
+	if err != errInvalidPageToken {
+		t.Errorf("ListPosts() error = %v, want %v", err, errInvalidPageToken)
+	}
+}
+
+func Test_repositoryImplementation_GetPost_notFound(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	deleted := r.entClient.Post.Create().SetTitle("deleted").SetContent("content").SetAuthor("author").
+		SetPublishedOn(time.Now()).SetTags([]string{}).SetIsDeleted(true).SaveX(ctx)
+
+	for _, id := range []uuid.UUID{uuid.New(), deleted.ID} {
+		_, err := r.GetPost(ctx, id)
+		if domainerror.KindOf(err) != domainerror.KindNotFound {
+			t.Errorf("GetPost(%s) error = %v, want not found", id, err)
+		}
+	}
+}
+
+func Test_repositoryImplementation_GetPostOwner(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{}, Owner: "alice"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	if created.Owner != "alice" {
+		t.Errorf("CreatePost() owner = %q, want alice", created.Owner)
+	}
+	if err := r.DeletePost(ctx, created.ID, entity.DeletePostRequest{}); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+
+	owner, err := r.GetPostOwner(ctx, created.ID)
+	if err != nil || owner != "alice" {
+		t.Errorf("GetPostOwner() of a deleted post = %q, %v, want alice", owner, err)
+	}
+	_, err = r.GetPostOwner(ctx, uuid.New())
+	if domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("GetPostOwner() error = %v, want not found", err)
+	}
+}
+
+func Test_repositoryImplementation_UpdatePost(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	publishedOn := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: publishedOn, Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+
+	newTitle, newContent := "new title", "ignored content"
+	newPublishedOn := publishedOn.AddDate(0, 1, 0)
+	got, err := r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{
+		Title:       &newTitle,
+		Content:     &newContent,
+		PublishedOn: &newPublishedOn,
+		UpdateMask:  []string{entity.PostFieldTitle, entity.PostFieldPublishedOn, entity.PostFieldTags},
+	})
+	if err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	if got.Title != newTitle || got.Content != "content" || !got.PublishedOn.Equal(newPublishedOn) ||
+		len(got.Tags) != 0 {
+		t.Errorf("UpdatePost() got = %+v, want title, published_on and cleared tags updated only", got)
+	}
+
+	_, err = r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{UpdateMask: []string{"is_deleted"}})
+	if domainerror.KindOf(err) != domainerror.KindInvalidArgument {
+		t.Errorf("UpdatePost() error = %v, want invalid argument for unknown path", err)
+	}
+
+	_, err = r.UpdatePost(ctx, uuid.New(), entity.UpdatePostRequest{Title: &newTitle,
+		UpdateMask: []string{entity.PostFieldTitle}})
+	if domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("UpdatePost() error = %v, want not found", err)
+	}
+}
+
+func Test_repositoryImplementation_expectedErrorsAreNotLogged(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+	core, logs := observer.New(zapcore.DebugLevel)
+	r.logger = zap.New(core)
+
+	live, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	missing := uuid.New()
+	title := "title"
+	calls := map[string]func() error{
+		"GetPost": func() error {
+			_, err := r.GetPost(ctx, missing)
+			return err
+		},
+		"GetPostOwner": func() error {
+			_, err := r.GetPostOwner(ctx, missing)
+			return err
+		},
+		"UpdatePost": func() error {
+			_, err := r.UpdatePost(ctx, live.ID, entity.UpdatePostRequest{Title: &title,
+				UpdateMask: []string{entity.PostFieldTitle}, ExpectedRevision: live.Revision + 1})
+			return err
+		},
+		"DeletePost": func() error {
+			return r.DeletePost(ctx, missing, entity.DeletePostRequest{})
+		},
+		"RestorePost": func() error {
+			_, err := r.RestorePost(ctx, live.ID, "")
+			return err
+		},
+		"PurgePost": func() error {
+			return r.PurgePost(ctx, live.ID)
+		},
+		"GetRevision": func() error {
+			_, err := r.GetRevision(ctx, live.ID, live.Revision+1)
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); domainerror.KindOf(err) == domainerror.KindInternal {
+			t.Errorf("%s() error = %v, want a domain error", name, err)
+		}
+	}
+	for _, entry := range logs.AllUntimed() {
+		t.Errorf("logged %q for an expected error", entry.Message)
+	}
+}
+
+func Test_repositoryImplementation_expectedRevision(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	if created.Revision != 1 {
+		t.Fatalf("CreatePost() revision = %d, want 1", created.Revision)
+	}
+
+	title := "first editor"
+	updated, err := r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Title: &title,
+		UpdateMask: []string{entity.PostFieldTitle}, ExpectedRevision: created.Revision})
+	if err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+	if updated.Revision != 2 {
+		t.Errorf("UpdatePost() revision = %d, want 2", updated.Revision)
+	}
+
+	title = "second editor"
+	_, err = r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Title: &title,
+		UpdateMask: []string{entity.PostFieldTitle}, ExpectedRevision: created.Revision})
+	if domainerror.KindOf(err) != domainerror.KindConflict {
+		t.Errorf("UpdatePost() error = %v, want conflict", err)
+	}
+
+	err = r.DeletePost(ctx, created.ID, entity.DeletePostRequest{ExpectedRevision: created.Revision})
+	if domainerror.KindOf(err) != domainerror.KindConflict {
+		t.Errorf("DeletePost() error = %v, want conflict", err)
+	}
+	if err = r.DeletePost(ctx, created.ID, entity.DeletePostRequest{ExpectedRevision: updated.Revision}); err != nil {
+		t.Errorf("DeletePost() error = %v", err)
+	}
+}
+
+func Test_repositoryImplementation_UpdatePost_revisionRecordedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	// a concurrent write already recorded the revision this update will take
+	r.entClient.PostRevision.Create().SetPostID(created.ID).SetRevision(created.Revision + 1).
+		SetTitle("title").SetContent("content").SetAuthor("author").SetPublishedOn(time.Now()).
+		SetTags([]string{}).ExecX(ctx)
+
+	title := "new title"
+	_, err = r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Title: &title,
+		UpdateMask: []string{entity.PostFieldTitle}})
+	if domainerror.KindOf(err) != domainerror.KindConflict {
+		t.Errorf("UpdatePost() error = %v, want conflict", err)
+	}
+}
+
+func Test_repositoryImplementation_revisions(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{"go"}, Owner: "owner"})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	content := "new content"
+	if _, err := r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Content: &content,
+		UpdateMask: []string{entity.PostFieldContent}, ChangedBy: "editor"}); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+
+	page, err := r.ListRevisions(ctx, created.ID, entity.ListRevisionsRequest{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListRevisions() error = %v", err)
+	}
+	revisions := page.Revisions
+	if len(revisions) != 2 || revisions[0].Revision != 2 || revisions[1].Revision != 1 {
+		t.Fatalf("ListRevisions() got = %+v, want revisions 2 and 1", revisions)
+	}
+	if revisions[0].Content != content || revisions[1].Content != "content" {
+		t.Errorf("ListRevisions() contents = %q, %q", revisions[0].Content, revisions[1].Content)
+	}
+	if revisions[0].ChangedBy != "editor" || revisions[1].ChangedBy != "owner" {
+		t.Errorf("ListRevisions() changed by = %q, %q, want editor and owner", revisions[0].ChangedBy,
+			revisions[1].ChangedBy)
+	}
+
+	revision, err := r.GetRevision(ctx, created.ID, 1)
+	if err != nil {
+		t.Fatalf("GetRevision() error = %v", err)
+	}
+	if revision.Title != "title" || !reflect.DeepEqual(revision.Tags, []string{"go"}) {
+		t.Errorf("GetRevision() got = %+v", revision)
+	}
+	if _, err := r.GetRevision(ctx, created.ID, 3); domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("GetRevision() error = %v, want not found", err)
+	}
+
+	// a failed conditional write must not leave a revision behind
+	_, err = r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Content: &content,
+		UpdateMask: []string{entity.PostFieldContent}, ExpectedRevision: 1})
+	if domainerror.KindOf(err) != domainerror.KindConflict {
+		t.Fatalf("UpdatePost() error = %v, want conflict", err)
+	}
+	if page, _ := r.ListRevisions(ctx, created.ID, entity.ListRevisionsRequest{PageSize: 10}); len(page.Revisions) != 2 {
+		t.Errorf("ListRevisions() got %d revisions, want 2", len(page.Revisions))
+	}
+}
+
+func Test_repositoryImplementation_ListRevisions_pages(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		title := fmt.Sprintf("title %d", i)
+		if _, err := r.UpdatePost(ctx, created.ID, entity.UpdatePostRequest{Title: &title,
+			UpdateMask: []string{entity.PostFieldTitle}}); err != nil {
+			t.Fatalf("UpdatePost() error = %v", err)
+		}
+	}
+
+	var got []int64
+	request := entity.ListRevisionsRequest{PageSize: 2}
+	for pages := 0; ; pages++ {
+		if pages == 3 {
+			t.Fatal("ListRevisions() did not stop after 3 pages")
+		}
+		page, err := r.ListRevisions(ctx, created.ID, request)
+		if err != nil {
+			t.Fatalf("ListRevisions() error = %v", err)
+		}
+		for _, revision := range page.Revisions {
+			got = append(got, revision.Revision)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		request.PageToken = page.NextPageToken
+	}
+	if want := []int64{5, 4, 3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListRevisions() pages got revisions %v, want %v", got, want)
+	}
+
+	other, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "other", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	first, _ := r.ListRevisions(ctx, created.ID, entity.ListRevisionsRequest{PageSize: 1})
+	for _, token := range []string{"not a token", first.NextPageToken} {
+		_, err := r.ListRevisions(ctx, other.ID, entity.ListRevisionsRequest{PageSize: 1, PageToken: token})
+		if domainerror.KindOf(err) != domainerror.KindInvalidArgument {
+			t.Errorf("ListRevisions() with page token %q error = %v, want invalid argument", token, err)
+		}
+	}
+}
+
+func Test_repositoryImplementation_restoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	create := func() *entity.PostDetail {
+		created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+			Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+		if err != nil {
+			t.Fatalf("CreatePost() error = %v", err)
+		}
+		return created
+	}
+
+	restored := create()
+	if _, err := r.RestorePost(ctx, restored.ID, "admin"); domainerror.KindOf(err) != domainerror.KindFailedPrecondition {
+		t.Errorf("RestorePost() error = %v, want failed precondition for a live post", err)
+	}
+	if err := r.DeletePost(ctx, restored.ID, entity.DeletePostRequest{DeletedBy: "editor"}); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	deleted := r.entClient.Post.GetX(ctx, restored.ID)
+	if deleted.DeletedAt == nil || deleted.DeletedBy == nil || *deleted.DeletedBy != "editor" {
+		t.Errorf("DeletePost() did not record deleted_at and deleted_by: %+v", deleted)
+	}
+	got, err := r.RestorePost(ctx, restored.ID, "admin")
+	if err != nil {
+		t.Fatalf("RestorePost() error = %v", err)
+	}
+	if got.Revision != 3 {
+		t.Errorf("RestorePost() revision = %d, want 3", got.Revision)
+	}
+	page, err := r.ListRevisions(ctx, restored.ID, entity.ListRevisionsRequest{PageSize: 10})
+	if err != nil {
+		t.Fatalf("ListRevisions() error = %v", err)
+	}
+	if len(page.Revisions) != 3 || page.Revisions[0].ChangedBy != "admin" || page.Revisions[1].ChangedBy != "editor" {
+		t.Errorf("ListRevisions() got = %+v, want the restore by admin after the delete by editor", page.Revisions)
+	}
+	if _, err := r.GetPost(ctx, restored.ID); err != nil {
+		t.Errorf("GetPost() after restore error = %v", err)
+	}
+
+	purged := create()
+	if err := r.PurgePost(ctx, purged.ID); domainerror.KindOf(err) != domainerror.KindFailedPrecondition {
+		t.Errorf("PurgePost() error = %v, want failed precondition for a live post", err)
+	}
+	if err := r.DeletePost(ctx, purged.ID, entity.DeletePostRequest{}); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	if err := r.PurgePost(ctx, purged.ID); err != nil {
+		t.Fatalf("PurgePost() error = %v", err)
+	}
+	if err := r.PurgePost(ctx, purged.ID); domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("PurgePost() error = %v, want not found", err)
+	}
+	if page, _ := r.ListRevisions(ctx, purged.ID, entity.ListRevisionsRequest{PageSize: 10}); len(page.Revisions) != 0 {
+		t.Errorf("PurgePost() left %d revisions behind", len(page.Revisions))
+	}
+
+	expired, recent := create(), create()
+	for _, p := range []*entity.PostDetail{expired, recent} {
+		if err := r.DeletePost(ctx, p.ID, entity.DeletePostRequest{}); err != nil {
+			t.Fatalf("DeletePost() error = %v", err)
+		}
+	}
+	r.entClient.Post.UpdateOneID(expired.ID).SetDeletedAt(time.Now().AddDate(0, -2, 0)).ExecX(ctx)
+
+	count, err := r.PurgeDeletedPosts(ctx, time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("PurgeDeletedPosts() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("PurgeDeletedPosts() = %d, want 1", count)
+	}
+	if exists := r.entClient.Post.Query().Where(post.ID(recent.ID)).ExistX(ctx); !exists {
+		t.Error("PurgeDeletedPosts() removed a post inside the retention period")
+	}
+}
+
+func Test_repositoryImplementation_purgeDeletedPosts_batches(t *testing.T) {
+	for _, expired := range []int{4, 5} {
+		t.Run(fmt.Sprintf("%d expired posts", expired), func(t *testing.T) {
+			ctx := context.Background()
+			r := newTestRepository(t)
+
+			create := func(deletedAt time.Time) uuid.UUID {
+				created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+					Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+				if err != nil {
+					t.Fatalf("CreatePost() error = %v", err)
+				}
+				r.entClient.Post.UpdateOneID(created.ID).SetIsDeleted(true).SetDeletedAt(deletedAt).ExecX(ctx)
+				return created.ID
+			}
+			for i := 0; i < expired; i++ {
+				create(time.Now().AddDate(0, -2, 0))
+			}
+			recent := create(time.Now())
+
+			count, err := r.purgeDeletedPosts(ctx, time.Now().AddDate(0, -1, 0), 2)
+			if err != nil {
+				t.Fatalf("purgeDeletedPosts() error = %v", err)
+			}
+			if count != expired {
+				t.Errorf("purgeDeletedPosts() = %d, want %d", count, expired)
+			}
+			if ids := r.entClient.Post.Query().IDsX(ctx); len(ids) != 1 || ids[0] != recent {
+				t.Errorf("purgeDeletedPosts() left posts %v, want only %s", ids, recent)
+			}
+			if revisions := r.entClient.PostRevision.Query().Where(postrevision.PostIDNEQ(recent)).CountX(ctx); revisions != 0 {
+				t.Errorf("purgeDeletedPosts() left %d revisions of purged posts", revisions)
+			}
+		})
+	}
+}
+
+func Test_repositoryImplementation_DeletePost(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+
+	created, err := r.CreatePost(ctx, entity.CreatePostRequest{Title: "title", Content: "content",
+		Author: "author", PublishedOn: time.Now(), Tags: []string{}})
+	if err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+
+	if err := r.DeletePost(ctx, created.ID, entity.DeletePostRequest{DeletedBy: "first"}); err != nil {
+		t.Fatalf("DeletePost() error = %v", err)
+	}
+	if err := r.DeletePost(ctx, created.ID, entity.DeletePostRequest{DeletedBy: "second"}); err != nil {
+		t.Errorf("DeletePost() of a deleted post error = %v, want success", err)
+	}
+	deleted := r.entClient.Post.GetX(ctx, created.ID)
+	if deleted.Revision != 2 || deleted.DeletedBy == nil || *deleted.DeletedBy != "first" {
+		t.Errorf("DeletePost() of a deleted post changed it: %+v", deleted)
+	}
+
+	missing := uuid.New()
+	if err := r.DeletePost(ctx, missing, entity.DeletePostRequest{}); domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("DeletePost() error = %v, want not found", err)
+	}
+	if err := r.DeletePost(ctx, missing, entity.DeletePostRequest{AllowMissing: true}); err != nil {
+		t.Errorf("DeletePost() with allow missing error = %v", err)
+	}
+}
+
+func Test_repositoryImplementation_CountPosts(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRepository(t)
+	registry := prometheus.NewRegistry()
+	WithMetrics(registry)(r)
+
+	for _, deleted := range []bool{false, false, true} {
+		r.entClient.Post.Create().SetTitle("title").SetContent("content").SetAuthor("author").
+			SetPublishedOn(time.Now()).SetTags([]string{}).SetIsDeleted(deleted).SaveX(ctx)
+	}
+
+	got, err := r.CountPosts(ctx)
+	if err != nil {
+		t.Fatalf("CountPosts() error = %v", err)
+	}
+	if want := (&entity.PostCounts{Live: 2, Deleted: 1}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountPosts() = %+v, want %+v", got, want)
+	}
+	if count := testutil.CollectAndCount(registry, "cloudbees_repository_query_duration_seconds"); count != 1 {
+		t.Errorf("recorded %d operations, want count_posts only", count)
+	}
+}
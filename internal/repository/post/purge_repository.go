@@ -0,0 +1,124 @@
+package post
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/post"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
+	"go.uber.org/zap"
+	"time"
+)
+
+var errPostNotDeleted = domainerror.FailedPrecondition("post is not deleted")
+
+// RestorePost undoes a soft delete, recording restoredBy on the revision.
+func (r *repositoryImplementation) RestorePost(ctx context.Context, id uuid.UUID,
+	restoredBy string) (*entity.PostDetail, error) {
+	ctx, done := r.instrument(ctx, "restore_post")
+	defer done()
+	var resp *ent.Post
+	err := r.withTx(ctx, func(client *ent.Client) (err error) {
+		resp, err = client.Post.UpdateOneID(id).Where(post.IsDeleted(true)).
+			SetIsDeleted(false).ClearDeletedAt().ClearDeletedBy().SetChangedBy(restoredBy).Save(ctx)
+		return err
+	})
+	if err != nil {
+		err = r.checkDeleted(ctx, id, err)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in restoring post", zap.Error(err), zap.Any("postID", id))
+		}
+		return nil, err
+	}
+	return decoratePostEntity(*resp), nil
+}
+
+// PurgePost permanently removes a soft-deleted post together with its revisions.
+func (r *repositoryImplementation) PurgePost(ctx context.Context, id uuid.UUID) error {
+	ctx, done := r.instrument(ctx, "purge_post")
+	defer done()
+	err := r.withTx(ctx, func(client *ent.Client) error {
+		exists, err := client.Post.Query().Where(post.ID(id), post.IsDeleted(true)).Exist(ctx)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return &ent.NotFoundError{}
+		}
+		if _, err := client.PostRevision.Delete().Where(postrevision.PostID(id)).Exec(ctx); err != nil {
+			return err
+		}
+		return client.Post.DeleteOneID(id).Exec(ctx)
+	})
+	if err != nil {
+		err = r.checkDeleted(ctx, id, err)
+		if domainerror.KindOf(err) == domainerror.KindInternal {
+			r.log(ctx).Error("error in purging post", zap.Error(err), zap.Any("postID", id))
+		}
+		return err
+	}
+	return nil
+}
+
+// purgeBatchSize is the number of posts PurgeDeletedPosts removes per
+// transaction, so that neither the IN lists nor the transactions grow with
+// the backlog of deleted posts.
+const purgeBatchSize = 500
+
+// PurgeDeletedPosts permanently removes posts soft-deleted before the given
+// time and returns how many were removed. Posts are removed in batches of
+// purgeBatchSize, each in its own transaction, so after an error the batches
+// before it stay removed and are counted.
+func (r *repositoryImplementation) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, done := r.instrument(ctx, "purge_deleted_posts")
+	defer done()
+	purged, err := r.purgeDeletedPosts(ctx, deletedBefore, purgeBatchSize)
+	if err != nil {
+		r.log(ctx).Error("error in purging deleted posts", zap.Error(err), zap.Time("deletedBefore", deletedBefore),
+			zap.Int("purged", purged))
+	}
+	return purged, err
+}
+
+func (r *repositoryImplementation) purgeDeletedPosts(ctx context.Context, deletedBefore time.Time,
+	batchSize int) (int, error) {
+	var purged int
+	for {
+		var batch int
+		err := r.withTx(ctx, func(client *ent.Client) error {
+			ids, err := client.Post.Query().Where(post.IsDeleted(true), post.DeletedAtLT(deletedBefore)).
+				Limit(batchSize).IDs(ctx)
+			if err != nil || len(ids) == 0 {
+				return err
+			}
+			// revisions are removed explicitly, SQLite only cascades with foreign keys enabled
+			if _, err := client.PostRevision.Delete().Where(postrevision.PostIDIn(ids...)).Exec(ctx); err != nil {
+				return err
+			}
+			batch, err = client.Post.Delete().Where(post.IDIn(ids...)).Exec(ctx)
+			return err
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += batch
+		if batch < batchSize {
+			return purged, nil
+		}
+	}
+}
+
+// checkDeleted tells a post that is not soft-deleted apart from a missing one
+// after a write restricted to deleted posts matched no row.
+func (r *repositoryImplementation) checkDeleted(ctx context.Context, id uuid.UUID, err error) error {
+	if !ent.IsNotFound(err) {
+		return toDomainError(err, id)
+	}
+	exists, existErr := r.entClient.Post.Query().Where(post.ID(id), post.IsDeleted(false)).Exist(ctx)
+	if existErr == nil && exists {
+		return errPostNotDeleted
+	}
+	return toDomainError(err, id)
+}
@@ -0,0 +1,79 @@
+package post
+
+import (
+	"context"
+	"entgo.io/ent/dialect/sql"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent"
+	"github.com/sdoshi579/cloudbees/internal/repository/ent/postrevision"
+	"go.uber.org/zap"
+)
+
+const resourcePostRevision = "post revision"
+
+func (r *repositoryImplementation) ListRevisions(ctx context.Context, postID uuid.UUID,
+	request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error) {
+	ctx, done := r.instrument(ctx, "list_revisions")
+	defer done()
+	query := r.entClient.PostRevision.Query().Where(postrevision.PostID(postID))
+
+	if request.PageToken != "" {
+		token, err := decodeRevisionPageToken(request.PageToken, postID)
+		if err != nil {
+			return nil, err
+		}
+		query.Where(postrevision.RevisionLT(token.Revision))
+	}
+
+	// fetch one extra row to find out whether another page exists
+	resp, err := query.Order(postrevision.ByRevision(sql.OrderDesc())).Limit(request.PageSize + 1).All(ctx)
+	if err != nil {
+		r.log(ctx).Error("error in listing post revisions", zap.Error(err), zap.Any("postID", postID))
+		return nil, err
+	}
+
+	result := &entity.ListRevisionsResponse{}
+	if len(resp) > request.PageSize {
+		resp = resp[:request.PageSize]
+		result.NextPageToken = revisionPageToken{PostID: postID, Revision: resp[len(resp)-1].Revision}.encode()
+	}
+	result.Revisions = make([]*entity.PostRevision, 0, len(resp))
+	for _, revision := range resp {
+		result.Revisions = append(result.Revisions, decorateRevisionEntity(*revision))
+	}
+	return result, nil
+}
+
+func (r *repositoryImplementation) GetRevision(ctx context.Context, postID uuid.UUID,
+	revision int64) (*entity.PostRevision, error) {
+	ctx, done := r.instrument(ctx, "get_revision")
+	defer done()
+	resp, err := r.entClient.PostRevision.Query().
+		Where(postrevision.PostID(postID), postrevision.Revision(revision)).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, domainerror.NotFound(resourcePostRevision, fmt.Sprintf("%s@%d", postID, revision)).Wrap(err)
+		}
+		r.log(ctx).Error("error in fetching post revision", zap.Error(err), zap.Any("postID", postID),
+			zap.Int64("revision", revision))
+		return nil, err
+	}
+	return decorateRevisionEntity(*resp), nil
+}
+
+func decorateRevisionEntity(revisionEnt ent.PostRevision) *entity.PostRevision {
+	return &entity.PostRevision{
+		PostID:      revisionEnt.PostID,
+		Revision:    revisionEnt.Revision,
+		Title:       revisionEnt.Title,
+		Content:     revisionEnt.Content,
+		Author:      revisionEnt.Author,
+		PublishedOn: revisionEnt.PublishedOn,
+		Tags:        revisionEnt.Tags,
+		ChangedBy:   revisionEnt.ChangedBy,
+		CreatedAt:   revisionEnt.CreatedAt,
+	}
+}
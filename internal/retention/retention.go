@@ -0,0 +1,59 @@
+// Package retention permanently removes posts once they have been
+// soft-deleted for longer than the retention period.
+package retention
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type Purger interface {
+	PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+type Job struct {
+	purger   Purger
+	period   time.Duration
+	interval time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewJob returns a job purging posts soft-deleted more than period ago, checking every interval.
+func NewJob(purger Purger, period, interval time.Duration, logger *zap.Logger) *Job {
+	return &Job{
+		purger:   purger,
+		period:   period,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Run purges once right away and then on every tick until ctx is done.
+func (j *Job) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges the posts past the retention period. Errors are logged by
+// the purger, the job only reports how many posts it removed and whether it
+// got through all of them.
+func (j *Job) RunOnce(ctx context.Context) {
+	deletedBefore := j.now().Add(-j.period)
+	purged, err := j.purger.PurgeDeletedPosts(ctx, deletedBefore)
+	if purged > 0 {
+		j.logger.Info("purged deleted posts", zap.Int("count", purged), zap.Time("deletedBefore", deletedBefore),
+			zap.Bool("complete", err == nil))
+	}
+}
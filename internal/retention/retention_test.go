@@ -0,0 +1,69 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"testing"
+	"time"
+)
+
+type fakePurger struct {
+	calls         int
+	deletedBefore time.Time
+	err           error
+}
+
+func (f *fakePurger) PurgeDeletedPosts(_ context.Context, deletedBefore time.Time) (int, error) {
+	f.calls++
+	f.deletedBefore = deletedBefore
+	return 1, f.err
+}
+
+func TestJob_RunOnce(t *testing.T) {
+	now := time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)
+	purger := &fakePurger{}
+	core, logs := observer.New(zapcore.DebugLevel)
+	job := NewJob(purger, 30*24*time.Hour, time.Hour, zap.New(core))
+	job.now = func() time.Time { return now }
+
+	job.RunOnce(context.Background())
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC); !purger.deletedBefore.Equal(want) {
+		t.Errorf("RunOnce() deletedBefore = %v, want %v", purger.deletedBefore, want)
+	}
+
+	purger.err = errors.New("database is locked")
+	job.RunOnce(context.Background())
+	if purger.calls != 2 {
+		t.Errorf("RunOnce() calls = %d, want 2", purger.calls)
+	}
+	// the purger logs its errors, the job only reports what it removed
+	entries := logs.All()
+	if len(entries) != 2 || entries[1].Level != zapcore.InfoLevel || entries[1].ContextMap()["complete"] != false {
+		t.Errorf("RunOnce() logged %+v, want one info entry per run with the second incomplete", entries)
+	}
+}
+
+func TestJob_Run_stopsWithContext(t *testing.T) {
+	purger := &fakePurger{}
+	job := NewJob(purger, time.Hour, time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		job.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after the context was done")
+	}
+	if purger.calls == 0 {
+		t.Error("Run() never purged")
+	}
+}
@@ -0,0 +1,139 @@
+// Package server runs the gRPC server until it is told to stop and then
+// drains in-flight requests before returning.
+package server
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"time"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+type Server struct {
+	grpcServer   *grpc.Server
+	httpServer   *http.Server
+	httpListener net.Listener
+	onShutdown   []func()
+	drainTimeout time.Duration
+	logger       *zap.Logger
+}
+
+type ServerConfiguration func(s *Server)
+
+func NewServer(grpcServer *grpc.Server, configs ...ServerConfiguration) *Server {
+	s := Server{grpcServer: grpcServer, drainTimeout: defaultDrainTimeout, logger: zap.NewNop()}
+	for _, config := range configs {
+		config(&s)
+	}
+	return &s
+}
+
+func WithLogger(logger *zap.Logger) ServerConfiguration {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithDrainTimeout bounds how long in-flight requests may take to finish on
+// shutdown before they are cancelled.
+func WithDrainTimeout(timeout time.Duration) ServerConfiguration {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// WithHTTPServer serves httpServer on lis next to the gRPC server. It is shut
+// down after the gRPC server has drained, so probes keep answering meanwhile.
+func WithHTTPServer(httpServer *http.Server, lis net.Listener) ServerConfiguration {
+	return func(s *Server) {
+		s.httpServer = httpServer
+		s.httpListener = lis
+	}
+}
+
+// WithOnShutdown registers fn to run when shutdown starts, before draining.
+func WithOnShutdown(fn func()) ServerConfiguration {
+	return func(s *Server) {
+		s.onShutdown = append(s.onShutdown, fn)
+	}
+}
+
+// Serve accepts connections on lis until ctx is done. It then stops accepting
+// new requests and waits up to the drain timeout for in-flight ones before
+// closing the remaining connections. It returns nil after a shutdown and the
+// serving error if a server failed on its own, which also shuts down the rest.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	running := 1
+	served := make(chan error, 2)
+	go func() {
+		err := s.grpcServer.Serve(lis)
+		if errors.Is(err, grpc.ErrServerStopped) {
+			err = nil
+		}
+		served <- err
+	}()
+	if s.httpServer != nil {
+		running++
+		go func() {
+			err := s.httpServer.Serve(s.httpListener)
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			served <- err
+		}()
+	}
+
+	var serveErr error
+	select {
+	case serveErr = <-served:
+		running--
+	case <-ctx.Done():
+	}
+
+	s.shutdown()
+	for ; running > 0; running-- {
+		if err := <-served; serveErr == nil {
+			serveErr = err
+		}
+	}
+	return serveErr
+}
+
+func (s *Server) shutdown() {
+	s.logger.Info("shutting down, draining in-flight requests", zap.Duration("drainTimeout", s.drainTimeout))
+	for _, fn := range s.onShutdown {
+		fn()
+	}
+	deadline := time.Now().Add(s.drainTimeout)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		s.logger.Info("drained all requests")
+	case <-timer.C:
+		s.logger.Warn("drain timeout exceeded, cancelling remaining requests")
+		s.grpcServer.Stop()
+		<-stopped
+	}
+
+	if s.httpServer == nil {
+		return
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.logger.Warn("error in draining http server", zap.Error(err))
+		s.httpServer.Close()
+	}
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mock_post "github.com/sdoshi579/cloudbees/internal/mockgen/service/post"
+	postrpc "github.com/sdoshi579/cloudbees/rpc/post"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// startServer serves a post service whose GetPost signals started and then
+// blocks until release is closed or the request is cancelled.
+func startServer(t *testing.T, drainTimeout time.Duration, started chan<- struct{}, release <-chan struct{}) (
+	postv1.PostServiceClient, context.CancelFunc, <-chan error) {
+
+	ctrl := gomock.NewController(t)
+	service := mock_post.NewMockService(ctrl)
+	service.EXPECT().GetPost(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error) {
+			close(started)
+			select {
+			case <-release:
+				return &entity.PostDetail{ID: id, Title: "title"}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+
+	grpcServer := grpc.NewServer()
+	postv1.RegisterPostServiceServer(grpcServer, postrpc.NewRPCImplementation(service, zap.NewNop()))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- NewServer(grpcServer, WithDrainTimeout(drainTimeout)).Serve(ctx, lis)
+	}()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return postv1.NewPostServiceClient(conn), cancel, served
+}
+
+func getPost(client postv1.PostServiceClient) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Get(context.Background(), &postv1.GetRequest{Id: uuid.NewString()})
+		done <- err
+	}()
+	return done
+}
+
+func TestServer_Serve_drainsInFlightRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	client, shutdown, served := startServer(t, 5*time.Second, started, release)
+
+	done := getPost(client)
+	<-started
+	shutdown()
+
+	// the server must wait for the request instead of returning right away
+	select {
+	case err := <-served:
+		t.Fatalf("Serve() returned %v before the in-flight request finished", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("in-flight Get() error = %v, want it to complete", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+}
+
+func TestServer_Serve_cancelsRequestsAfterDrainTimeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	client, shutdown, served := startServer(t, 50*time.Millisecond, started, release)
+
+	done := getPost(client)
+	<-started
+	shutdown()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after the drain timeout")
+	}
+	if err := <-done; status.Code(err) != codes.Unavailable && status.Code(err) != codes.Canceled {
+		t.Errorf("in-flight Get() error = %v, want it cancelled", err)
+	}
+}
+
+func TestServer_Serve_listenerError(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis.Close()
+
+	if err := NewServer(grpc.NewServer()).Serve(context.Background(), lis); err == nil {
+		t.Error("Serve() on a closed listener error = nil")
+	}
+}
+
+func TestServer_Serve_shutsDownHTTPAfterHooks(t *testing.T) {
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shuttingDown := make(chan struct{})
+	httpServer := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-shuttingDown:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+		}
+	})}
+	srv := NewServer(grpc.NewServer(),
+		WithHTTPServer(httpServer, httpListener),
+		WithOnShutdown(func() { close(shuttingDown) }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ctx, grpcListener) }()
+
+	url := "http://" + httpListener.Addr().String()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET before shutdown error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET before shutdown = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve() error = %v", err)
+	}
+	select {
+	case <-shuttingDown:
+	default:
+		t.Error("shutdown hook did not run")
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("http server still accepts requests after shutdown")
+	}
+}
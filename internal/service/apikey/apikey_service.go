@@ -0,0 +1,210 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/logging"
+	"github.com/sdoshi579/cloudbees/internal/repository/apikey"
+	"go.uber.org/zap"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -destination=../../mockgen/service/apikey/apikey_service.go -source=./apikey_service.go Service
+type Service interface {
+	CreateAPIKey(ctx context.Context, request entity.CreateAPIKeyRequest) (*entity.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+	ResolveAPIKey(ctx context.Context, key string) (*auth.Principal, error)
+}
+
+const (
+	// keyPrefix marks the keys minted here, so they are easy to spot in
+	// configuration and by secret scanners.
+	keyPrefix = "cbk_"
+	// keyBytes is the amount of randomness in a key, which makes a plain
+	// SHA-256 hash sufficient for storing it.
+	keyBytes = 32
+	// displayPrefixLength is how much of a key is kept to identify it.
+	displayPrefixLength = len(keyPrefix) + 8
+	// subjectPrefix sets the principals of API keys apart from JWT subjects.
+	subjectPrefix = "apikey:"
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+type serviceImplementation struct {
+	repository    apikey.Repository
+	logger        *zap.Logger
+	authorization bool
+}
+
+type ServiceConfiguration func(r *serviceImplementation)
+
+func NewService(configs ...ServiceConfiguration) Service {
+	r := serviceImplementation{}
+	for _, config := range configs {
+		config(&r)
+	}
+	return &r
+}
+
+func WithLogger(logger *zap.Logger) ServiceConfiguration {
+	return func(r *serviceImplementation) {
+		r.logger = logger
+	}
+}
+
+// log returns the logger of the call ctx belongs to, or the service logger.
+func (s *serviceImplementation) log(ctx context.Context) *zap.Logger {
+	return logging.FromContext(ctx, s.logger)
+}
+
+func WithRepository(repository apikey.Repository) ServiceConfiguration {
+	return func(r *serviceImplementation) {
+		r.repository = repository
+	}
+}
+
+// WithAuthorization restricts minting, listing and revoking keys to callers
+// with the admin role.
+func WithAuthorization() ServiceConfiguration {
+	return func(r *serviceImplementation) {
+		r.authorization = true
+	}
+}
+
+// CreateAPIKey mints a key. The returned secret is not stored, only its hash.
+func (s *serviceImplementation) CreateAPIKey(ctx context.Context,
+	request entity.CreateAPIKeyRequest) (*entity.CreatedAPIKey, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateCreateAPIKey(&request, time.Now()); err != nil {
+		return nil, err
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		request.CreatedBy = principal.Subject
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		s.log(ctx).Error("error in generating api key", zap.Error(err))
+		return nil, err
+	}
+	request.KeyHash = hashKey(key)
+	request.KeyPrefix = key[:displayPrefixLength]
+	resp, err := s.repository.CreateAPIKey(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.CreatedAPIKey{APIKey: *resp, Key: key}, nil
+}
+
+func (s *serviceImplementation) ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repository.ListAPIKeys(ctx)
+}
+
+// RevokeAPIKey makes a key unusable, it is kept for the record.
+func (s *serviceImplementation) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return s.repository.RevokeAPIKey(ctx, id)
+}
+
+// ResolveAPIKey returns the principal of a key with the roles of its scopes,
+// or an error wrapping auth.ErrInvalidAPIKey for unknown, revoked and
+// expired keys.
+func (s *serviceImplementation) ResolveAPIKey(ctx context.Context, key string) (*auth.Principal, error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	resp, err := s.repository.GetAPIKeyByHash(ctx, hashKey(key))
+	switch {
+	case domainerror.KindOf(err) == domainerror.KindNotFound:
+		return nil, auth.ErrInvalidAPIKey
+	case err != nil:
+		return nil, err
+	case resp.RevokedAt != nil:
+		return nil, fmt.Errorf("%w: key %s was revoked", auth.ErrInvalidAPIKey, resp.KeyPrefix)
+	case resp.ExpiresAt != nil && !time.Now().Before(*resp.ExpiresAt):
+		return nil, fmt.Errorf("%w: key %s expired", auth.ErrInvalidAPIKey, resp.KeyPrefix)
+	}
+
+	principal := &auth.Principal{Subject: subjectPrefix + resp.Name}
+	for _, scope := range resp.Scopes {
+		if role := auth.Role(scope); role.Valid() {
+			principal.Roles = append(principal.Roles, role)
+		}
+	}
+	return principal, nil
+}
+
+func (s *serviceImplementation) authorize(ctx context.Context) error {
+	if !s.authorization {
+		return nil
+	}
+	if principal, ok := auth.FromContext(ctx); ok && principal.HasRole(auth.RoleAdmin) {
+		return nil
+	}
+	return domainerror.PermissionDenied(fmt.Sprintf("the %s role is required", auth.RoleAdmin))
+}
+
+func validateCreateAPIKey(request *entity.CreateAPIKeyRequest, now time.Time) error {
+	var violations []domainerror.FieldViolation
+	if !namePattern.MatchString(request.Name) {
+		violations = append(violations, domainerror.FieldViolation{Field: "name",
+			Description: "must be 1-63 lowercase letters, digits or hyphens and start with a letter or digit"})
+	}
+	if len(request.Scopes) == 0 {
+		violations = append(violations, domainerror.FieldViolation{Field: "scopes", Description: "is required"})
+	}
+	seen := make(map[string]bool, len(request.Scopes))
+	scopes := make([]string, 0, len(request.Scopes))
+	for i, scope := range request.Scopes {
+		if !auth.Role(scope).Valid() {
+			violations = append(violations, domainerror.FieldViolation{Field: fmt.Sprintf("scopes[%d]", i),
+				Description: "must be one of reader, author, editor or admin"})
+			continue
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	request.Scopes = scopes
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		violations = append(violations, domainerror.FieldViolation{Field: "expires_at",
+			Description: "must be in the future"})
+	}
+	if len(violations) != 0 {
+		return domainerror.InvalidArgument("invalid api key", violations...)
+	}
+	return nil
+}
+
+func generateKey() (string, error) {
+	random := make([]byte, keyBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,154 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mockapikeyrepository "github.com/sdoshi579/cloudbees/internal/mockgen/repository/apikey"
+	"go.uber.org/zap"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func adminContext() context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: "alice", Roles: []auth.Role{auth.RoleAdmin}})
+}
+
+func Test_serviceImplementation_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mockapikeyrepository.NewMockRepository(ctrl)
+	s := &serviceImplementation{repository: mockRepo, logger: zap.NewExample(), authorization: true}
+
+	var stored entity.CreateAPIKeyRequest
+	mockRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request entity.CreateAPIKeyRequest) (*entity.APIKey, error) {
+			stored = request
+			return &entity.APIKey{Name: request.Name, KeyPrefix: request.KeyPrefix, Scopes: request.Scopes}, nil
+		})
+
+	created, err := s.CreateAPIKey(adminContext(), entity.CreateAPIKeyRequest{Name: "importer",
+		Scopes: []string{"author", "author"}})
+	if err != nil {
+		t.Fatalf("CreateAPIKey() error = %v", err)
+	}
+	if !strings.HasPrefix(created.Key, keyPrefix) || !strings.HasPrefix(created.Key, stored.KeyPrefix) {
+		t.Errorf("CreateAPIKey() key = %q, prefix = %q", created.Key, stored.KeyPrefix)
+	}
+	if stored.KeyHash != hashKey(created.Key) || strings.Contains(stored.KeyHash, created.Key) {
+		t.Errorf("CreateAPIKey() stored hash %q does not match the key", stored.KeyHash)
+	}
+	if stored.CreatedBy != "alice" || !reflect.DeepEqual(stored.Scopes, []string{"author"}) {
+		t.Errorf("CreateAPIKey() stored = %+v, want created by alice with deduplicated scopes", stored)
+	}
+}
+
+func Test_serviceImplementation_CreateAPIKey_errors(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		request entity.CreateAPIKeyRequest
+		kind    domainerror.Kind
+		fields  []string
+	}{
+		{
+			name:    "not an admin",
+			ctx:     auth.NewContext(context.Background(), &auth.Principal{Subject: "bob", Roles: []auth.Role{auth.RoleEditor}}),
+			request: entity.CreateAPIKeyRequest{Name: "importer", Scopes: []string{"author"}},
+			kind:    domainerror.KindPermissionDenied,
+		},
+		{
+			name:    "unauthenticated",
+			ctx:     context.Background(),
+			request: entity.CreateAPIKeyRequest{Name: "importer", Scopes: []string{"author"}},
+			kind:    domainerror.KindPermissionDenied,
+		},
+		{
+			name:    "invalid request",
+			ctx:     adminContext(),
+			request: entity.CreateAPIKeyRequest{Name: "Batch Importer", Scopes: []string{"author", "root"}, ExpiresAt: &past},
+			kind:    domainerror.KindInvalidArgument,
+			fields:  []string{"name", "scopes[1]", "expires_at"},
+		},
+		{
+			name:    "no scopes",
+			ctx:     adminContext(),
+			request: entity.CreateAPIKeyRequest{Name: "importer"},
+			kind:    domainerror.KindInvalidArgument,
+			fields:  []string{"scopes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			s := &serviceImplementation{repository: mockapikeyrepository.NewMockRepository(ctrl),
+				logger: zap.NewExample(), authorization: true}
+
+			_, err := s.CreateAPIKey(tt.ctx, tt.request)
+			if domainerror.KindOf(err) != tt.kind {
+				t.Fatalf("CreateAPIKey() error = %v, want %v", err, tt.kind)
+			}
+			var domainErr *domainerror.Error
+			errors.As(err, &domainErr)
+			var fields []string
+			for _, violation := range domainErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("CreateAPIKey() violations = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func Test_serviceImplementation_ResolveAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mockapikeyrepository.NewMockRepository(ctrl)
+	s := &serviceImplementation{repository: mockRepo, logger: zap.NewExample()}
+
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	keys := map[string]*entity.APIKey{
+		"cbk_valid":   {ID: uuid.New(), Name: "importer", Scopes: []string{"author", "retired"}, ExpiresAt: &future},
+		"cbk_revoked": {ID: uuid.New(), Name: "old", Scopes: []string{"admin"}, RevokedAt: &past},
+		"cbk_expired": {ID: uuid.New(), Name: "temp", Scopes: []string{"reader"}, ExpiresAt: &past},
+	}
+	for key, value := range keys {
+		mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashKey(key)).Return(value, nil)
+	}
+	mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashKey("cbk_unknown")).
+		Return(nil, domainerror.NotFound("api key", ""))
+	mockRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), hashKey("cbk_broken")).Return(nil, errors.New("database is locked"))
+
+	tests := []struct {
+		key         string
+		want        *auth.Principal
+		wantInvalid bool
+	}{
+		{key: "cbk_valid", want: &auth.Principal{Subject: "apikey:importer", Roles: []auth.Role{auth.RoleAuthor}}},
+		{key: "cbk_revoked", wantInvalid: true},
+		{key: "cbk_expired", wantInvalid: true},
+		{key: "cbk_unknown", wantInvalid: true},
+		{key: "not-a-key", wantInvalid: true},
+		{key: "cbk_broken"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := s.ResolveAPIKey(context.Background(), tt.key)
+			if errors.Is(err, auth.ErrInvalidAPIKey) != tt.wantInvalid {
+				t.Fatalf("ResolveAPIKey() error = %v, want invalid = %v", err, tt.wantInvalid)
+			}
+			if tt.want == nil && err == nil {
+				t.Fatalf("ResolveAPIKey() = %+v, want an error", got)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveAPIKey() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+)
+
+var errNotOwner = domainerror.PermissionDenied("only the owner of the post or an editor can change it")
+
+// WithAuthorization enforces the roles of the calling principal: readers may
+// read posts, authors may also create posts and change their own, editors
+// may change any post and admins may also purge them. Calls without a
+// principal are denied.
+func WithAuthorization() ServiceConfiguration {
+	return func(r *serviceImplementation) {
+		r.authorization = true
+	}
+}
+
+// authorize checks that the caller was granted role. It returns the caller,
+// or nil when authorization is not enforced.
+func (s *serviceImplementation) authorize(ctx context.Context, role auth.Role) (*auth.Principal, error) {
+	if !s.authorization {
+		return nil, nil
+	}
+	principal, ok := auth.FromContext(ctx)
+	if !ok || !principal.HasRole(role) {
+		return nil, domainerror.PermissionDenied(fmt.Sprintf("the %s role is required", role))
+	}
+	return principal, nil
+}
+
+// authorizeChange checks that the caller may change a post owned by owner,
+// authors may only change their own posts.
+func authorizeChange(principal *auth.Principal, owner string) error {
+	if principal == nil || principal.HasRole(auth.RoleEditor) {
+		return nil
+	}
+	if owner == "" || owner != principal.Subject {
+		return errNotOwner
+	}
+	return nil
+}
+
+// authorizeChangeByID is authorizeChange for a post that may be soft-deleted.
+// A missing post is left to the repository to report.
+func (s *serviceImplementation) authorizeChangeByID(ctx context.Context, id uuid.UUID) error {
+	principal, err := s.authorize(ctx, auth.RoleAuthor)
+	if err != nil || principal == nil || principal.HasRole(auth.RoleEditor) {
+		return err
+	}
+	owner, err := s.repository.GetPostOwner(ctx, id)
+	if domainerror.KindOf(err) == domainerror.KindNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return authorizeChange(principal, owner)
+}
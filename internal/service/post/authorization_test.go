@@ -0,0 +1,222 @@
+package post
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mockpostrepository "github.com/sdoshi579/cloudbees/internal/mockgen/repository/post"
+	"testing"
+)
+
+func principalContext(subject string, roles ...auth.Role) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: subject, Roles: roles})
+}
+
+func Test_serviceImplementation_authorization(t *testing.T) {
+	postID := uuid.New()
+	ownPost := &entity.PostDetail{ID: postID, Title: "title", Owner: "alice"}
+	title := "new title"
+	update := entity.UpdatePostRequest{Title: &title, UpdateMask: []string{entity.PostFieldTitle}}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		expect func(repo *mockpostrepository.MockRepository)
+		call   func(s Service, ctx context.Context) error
+		// allowed calls succeed, the others fail with PermissionDenied
+		allowed bool
+	}{
+		{
+			name: "reader can get",
+			ctx:  principalContext("bob", auth.RoleReader),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPost(gomock.Any(), postID).Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.GetPost(ctx, postID)
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "reader can list",
+			ctx:  principalContext("bob", auth.RoleReader),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().ListPosts(gomock.Any(), gomock.Any()).Return(&entity.ListPostsResponse{}, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.ListPosts(ctx, entity.ListPostsRequest{})
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "unauthenticated call cannot get",
+			ctx:  context.Background(),
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.GetPost(ctx, postID)
+				return err
+			},
+		},
+		{
+			name: "reader cannot create",
+			ctx:  principalContext("bob", auth.RoleReader),
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.CreatePost(ctx, newCreatePostRequest("title"))
+				return err
+			},
+		},
+		{
+			name: "author creates posts it owns",
+			ctx:  principalContext("alice", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				request := newCreatePostRequest("title")
+				request.Owner = "alice"
+				repo.EXPECT().CreatePost(gomock.Any(), request).Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.CreatePost(ctx, newCreatePostRequest("title"))
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "author updates own post",
+			ctx:  principalContext("alice", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				changed := update
+				changed.ChangedBy = "alice"
+				repo.EXPECT().GetPost(gomock.Any(), postID).Return(ownPost, nil)
+				repo.EXPECT().UpdatePost(gomock.Any(), postID, changed).Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.UpdatePost(ctx, postID, update)
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "author cannot update the post of another",
+			ctx:  principalContext("bob", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPost(gomock.Any(), postID).Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.UpdatePost(ctx, postID, update)
+				return err
+			},
+		},
+		{
+			name: "author cannot update a post without owner",
+			ctx:  principalContext("alice", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPost(gomock.Any(), postID).Return(&entity.PostDetail{ID: postID}, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.UpdatePost(ctx, postID, update)
+				return err
+			},
+		},
+		{
+			name: "editor updates any post",
+			ctx:  principalContext("carol", auth.RoleEditor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				changed := update
+				changed.ChangedBy = "carol"
+				repo.EXPECT().GetPost(gomock.Any(), postID).Return(ownPost, nil)
+				repo.EXPECT().UpdatePost(gomock.Any(), postID, changed).Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.UpdatePost(ctx, postID, update)
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "author deletes own post",
+			ctx:  principalContext("alice", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPostOwner(gomock.Any(), postID).Return("alice", nil)
+				repo.EXPECT().DeletePost(gomock.Any(), postID, entity.DeletePostRequest{DeletedBy: "alice"}).Return(nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				return s.DeletePost(ctx, postID, entity.DeletePostRequest{})
+			},
+			allowed: true,
+		},
+		{
+			name: "author cannot delete the post of another",
+			ctx:  principalContext("bob", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPostOwner(gomock.Any(), postID).Return("alice", nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				return s.DeletePost(ctx, postID, entity.DeletePostRequest{})
+			},
+		},
+		{
+			name: "missing post is reported by the repository",
+			ctx:  principalContext("bob", auth.RoleAuthor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().GetPostOwner(gomock.Any(), postID).Return("", domainerror.NotFound("post", postID.String()))
+				repo.EXPECT().DeletePost(gomock.Any(), postID, entity.DeletePostRequest{DeletedBy: "bob", AllowMissing: true}).
+					Return(nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				return s.DeletePost(ctx, postID, entity.DeletePostRequest{AllowMissing: true})
+			},
+			allowed: true,
+		},
+		{
+			name: "editor restores any post",
+			ctx:  principalContext("carol", auth.RoleEditor),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().RestorePost(gomock.Any(), postID, "carol").Return(ownPost, nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				_, err := s.RestorePost(ctx, postID)
+				return err
+			},
+			allowed: true,
+		},
+		{
+			name: "editor cannot purge",
+			ctx:  principalContext("carol", auth.RoleEditor),
+			call: func(s Service, ctx context.Context) error {
+				return s.PurgePost(ctx, postID)
+			},
+		},
+		{
+			name: "admin purges",
+			ctx:  principalContext("dave", auth.RoleAdmin),
+			expect: func(repo *mockpostrepository.MockRepository) {
+				repo.EXPECT().PurgePost(gomock.Any(), postID).Return(nil)
+			},
+			call: func(s Service, ctx context.Context) error {
+				return s.PurgePost(ctx, postID)
+			},
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mockpostrepository.NewMockRepository(ctrl)
+			if tt.expect != nil {
+				tt.expect(mockRepo)
+			}
+			s := &serviceImplementation{repository: mockRepo, authorization: true}
+
+			err := tt.call(s, tt.ctx)
+			if tt.allowed && err != nil {
+				t.Fatalf("call error = %v, want it to be allowed", err)
+			}
+			if !tt.allowed && domainerror.KindOf(err) != domainerror.KindPermissionDenied {
+				t.Fatalf("call error = %v, want %v", err, domainerror.KindPermissionDenied)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package post
+
+import (
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"reflect"
+	"strings"
+)
+
+// maxEditCost bounds the number of line insertions and deletions searched
+// for when splitting a diff. Past it the lines left are reported as deleted
+// and inserted, so unrelated contents cost time linear in their length
+// instead of quadratic.
+const maxEditCost = 1024
+
+// diffLines returns a line based diff turning from into to. It uses Myers'
+// algorithm in linear space, recursing on the middle snake of the shortest
+// edit script.
+func diffLines(from, to string) []entity.DiffLine {
+	a, b := splitLines(from), splitLines(to)
+	return appendDiff(make([]entity.DiffLine, 0, max(len(a), len(b))), a, b)
+}
+
+func appendDiff(diff []entity.DiffLine, a, b []string) []entity.DiffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	diff = appendLines(diff, entity.DiffEqual, a[:prefix])
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	x, y, ok := middleSnake(a, b)
+	// a split must shrink both halves, or the recursion would not end
+	if ok && (x > 0 || y > 0) && (x < len(a) || y < len(b)) {
+		diff = appendDiff(diff, a[:x], b[:y])
+		diff = appendDiff(diff, a[x:], b[y:])
+	} else {
+		diff = appendLines(diff, entity.DiffDelete, a)
+		diff = appendLines(diff, entity.DiffInsert, b)
+	}
+	return appendLines(diff, entity.DiffEqual, common)
+}
+
+func appendLines(diff []entity.DiffLine, operation entity.DiffOperation, lines []string) []entity.DiffLine {
+	for _, line := range lines {
+		diff = append(diff, entity.DiffLine{Operation: operation, Text: line})
+	}
+	return diff
+}
+
+// middleSnake searches the shortest edit script of a and b from both ends at
+// once and returns where the two searches meet, which splits the diff into
+// two smaller ones. a and b must be non-empty and differ in their first and
+// last lines. It reports false when the script is longer than maxEditCost.
+func middleSnake(a, b []string) (x, y int, ok bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+	maxD := min((n+m+1)/2, maxEditCost)
+	// forward[offset+k] is the furthest x reached on diagonal k = x-y from the
+	// start, backward[offset+k] the same from the end
+	offset := maxD + 1
+	forward, backward := make([]int, 2*offset+1), make([]int, 2*offset+1)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+	delta := n - m
+	// with an odd delta the forward search is the one to meet the backward
+	// search of the same cost
+	odd := delta%2 != 0
+	// diagonals that ran off the edit graph are skipped from then on
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			i := offset + k
+			var x1 int
+			if k == -d || (k != d && forward[i-1] < forward[i+1]) {
+				x1 = forward[i+1]
+			} else {
+				x1 = forward[i-1] + 1
+			}
+			y1 := x1 - k
+			for x1 < n && y1 < m && a[x1] == b[y1] {
+				x1++
+				y1++
+			}
+			forward[i] = x1
+			switch {
+			case x1 > n:
+				forwardEnd += 2
+			case y1 > m:
+				forwardStart += 2
+			case odd:
+				if j := offset + delta - k; j >= 0 && j < len(backward) && backward[j] != -1 && x1 >= n-backward[j] {
+					return x1, y1, true
+				}
+			}
+		}
+		for k := -d + backwardStart; k <= d-backwardEnd; k += 2 {
+			i := offset + k
+			var x2 int
+			if k == -d || (k != d && backward[i-1] < backward[i+1]) {
+				x2 = backward[i+1]
+			} else {
+				x2 = backward[i-1] + 1
+			}
+			y2 := x2 - k
+			for x2 < n && y2 < m && a[n-x2-1] == b[m-y2-1] {
+				x2++
+				y2++
+			}
+			backward[i] = x2
+			switch {
+			case x2 > n:
+				backwardEnd += 2
+			case y2 > m:
+				backwardStart += 2
+			case !odd:
+				if j := offset + delta - k; j >= 0 && j < len(forward) && forward[j] != -1 && forward[j] >= n-x2 {
+					x1 := forward[j]
+					return x1, x1 - (delta - k), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func changedFields(from, to *entity.PostRevision) []string {
+	var fields []string
+	if from.Title != to.Title {
+		fields = append(fields, entity.PostFieldTitle)
+	}
+	if from.Content != to.Content {
+		fields = append(fields, entity.PostFieldContent)
+	}
+	if from.Author != to.Author {
+		fields = append(fields, entity.PostFieldAuthor)
+	}
+	if !from.PublishedOn.Equal(to.PublishedOn) {
+		fields = append(fields, entity.PostFieldPublishedOn)
+	}
+	if !reflect.DeepEqual(nonNil(from.Tags), nonNil(to.Tags)) {
+		fields = append(fields, entity.PostFieldTags)
+	}
+	return fields
+}
+
+func nonNil(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -2,11 +2,13 @@ package post
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
 	"github.com/sdoshi579/cloudbees/internal/entity"
 	"github.com/sdoshi579/cloudbees/internal/repository/post"
-	"go.uber.org/zap"
+	"go.opentelemetry.io/otel/trace"
+	"time"
 )
 
 //go:generate mockgen -destination=../../mockgen/service/post/post_service.go -source=./post_service.go Service
@@ -14,12 +16,33 @@ type Service interface {
 	CreatePost(ctx context.Context, request entity.CreatePostRequest) (*entity.PostDetail, error)
 	GetPost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error)
 	UpdatePost(ctx context.Context, id uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error)
-	DeletePost(ctx context.Context, id uuid.UUID) (bool, error)
+	DeletePost(ctx context.Context, id uuid.UUID, request entity.DeletePostRequest) error
+	ListPosts(ctx context.Context, request entity.ListPostsRequest) (*entity.ListPostsResponse, error)
+	RestorePost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error)
+	PurgePost(ctx context.Context, id uuid.UUID) error
+	PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error)
+	ListRevisions(ctx context.Context, postID uuid.UUID,
+		request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, postID uuid.UUID, revision int64) (*entity.PostRevision, error)
+	DiffRevisions(ctx context.Context, postID uuid.UUID, fromRevision, toRevision int64) (*entity.RevisionDiff, error)
+	RestoreRevision(ctx context.Context, postID uuid.UUID,
+		request entity.RestoreRevisionRequest) (*entity.PostDetail, error)
 }
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	// a revision holds up to maxContentLength characters of up to 4 bytes, so
+	// a full page of revisions stays below the 4 MB gRPC clients accept by
+	// default
+	defaultRevisionPageSize = 5
+	maxRevisionPageSize     = 8
+)
+
 type serviceImplementation struct {
-	repository post.Repository
-	logger     *zap.Logger
+	repository    post.Repository
+	authorization bool
+	tracer        trace.Tracer
 }
 
 type ServiceConfiguration func(r *serviceImplementation)
@@ -32,12 +55,6 @@ func NewService(configs ...ServiceConfiguration) Service {
 	return &r
 }
 
-func WithLogger(logger *zap.Logger) ServiceConfiguration {
-	return func(r *serviceImplementation) {
-		r.logger = logger
-	}
-}
-
 func WithRepository(repository post.Repository) ServiceConfiguration {
 	return func(r *serviceImplementation) {
 		r.repository = repository
@@ -45,24 +62,209 @@ func WithRepository(repository post.Repository) ServiceConfiguration {
 }
 
 func (s *serviceImplementation) CreatePost(ctx context.Context, request entity.CreatePostRequest) (*entity.PostDetail, error) {
+	ctx, done := s.instrument(ctx, "create_post")
+	defer done()
+	if _, err := s.authorize(ctx, auth.RoleAuthor); err != nil {
+		return nil, err
+	}
+	if err := validateCreatePost(&request, time.Now()); err != nil {
+		return nil, err
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		request.Owner = principal.Subject
+	}
 	return s.repository.CreatePost(ctx, request)
 }
 
 func (s *serviceImplementation) GetPost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error) {
+	ctx, done := s.instrument(ctx, "get_post")
+	defer done()
+	if _, err := s.authorize(ctx, auth.RoleReader); err != nil {
+		return nil, err
+	}
 	return s.repository.GetPost(ctx, id)
 }
 
 func (s *serviceImplementation) UpdatePost(ctx context.Context, id uuid.UUID,
 	request entity.UpdatePostRequest) (*entity.PostDetail, error) {
+	ctx, done := s.instrument(ctx, "update_post")
+	defer done()
 
-	_, err := s.repository.GetPost(ctx, id)
+	principal, err := s.authorize(ctx, auth.RoleAuthor)
 	if err != nil {
-		s.logger.Error("invalid post id for update", zap.Error(err), zap.Any("postID", id))
-		return nil, errors.New("post is not available or is deleted")
+		return nil, err
+	}
+	if err := validateUpdatePost(&request, time.Now()); err != nil {
+		return nil, err
+	}
+	current, err := s.repository.GetPost(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeChange(principal, current.Owner); err != nil {
+		return nil, err
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		request.ChangedBy = principal.Subject
 	}
 	return s.repository.UpdatePost(ctx, id, request)
 }
 
-func (s *serviceImplementation) DeletePost(ctx context.Context, id uuid.UUID) (bool, error) {
-	return s.repository.DeletePost(ctx, id)
+// DeletePost soft-deletes a post, recording the authenticated caller as the
+// one who deleted it.
+func (s *serviceImplementation) DeletePost(ctx context.Context, id uuid.UUID,
+	request entity.DeletePostRequest) error {
+	ctx, done := s.instrument(ctx, "delete_post")
+	defer done()
+	if err := s.authorizeChangeByID(ctx, id); err != nil {
+		return err
+	}
+	if principal, ok := auth.FromContext(ctx); ok {
+		request.DeletedBy = principal.Subject
+	}
+	return s.repository.DeletePost(ctx, id, request)
+}
+
+func (s *serviceImplementation) ListPosts(ctx context.Context,
+	request entity.ListPostsRequest) (*entity.ListPostsResponse, error) {
+	ctx, done := s.instrument(ctx, "list_posts")
+	defer done()
+	if _, err := s.authorize(ctx, auth.RoleReader); err != nil {
+		return nil, err
+	}
+
+	pageSize, err := pageSize(request.PageSize, defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	request.PageSize = pageSize
+
+	filter := request.Filter
+	if filter.PublishedAfter != nil && filter.PublishedBefore != nil &&
+		!filter.PublishedAfter.Before(*filter.PublishedBefore) {
+		return nil, domainerror.InvalidArgument("published_after must be before published_before",
+			domainerror.FieldViolation{
+				Field:       "filter.published_after",
+				Description: "must be before filter.published_before",
+			})
+	}
+	return s.repository.ListPosts(ctx, request)
+}
+
+// RestorePost undoes a soft delete, recording the authenticated caller as the
+// one who restored it.
+func (s *serviceImplementation) RestorePost(ctx context.Context, id uuid.UUID) (*entity.PostDetail, error) {
+	ctx, done := s.instrument(ctx, "restore_post")
+	defer done()
+	if err := s.authorizeChangeByID(ctx, id); err != nil {
+		return nil, err
+	}
+	var restoredBy string
+	if principal, ok := auth.FromContext(ctx); ok {
+		restoredBy = principal.Subject
+	}
+	return s.repository.RestorePost(ctx, id, restoredBy)
+}
+
+// PurgePost permanently removes a post, which has to be soft-deleted first.
+func (s *serviceImplementation) PurgePost(ctx context.Context, id uuid.UUID) error {
+	ctx, done := s.instrument(ctx, "purge_post")
+	defer done()
+	if _, err := s.authorize(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+	return s.repository.PurgePost(ctx, id)
+}
+
+// PurgeDeletedPosts is run by the retention job rather than on behalf of a
+// caller, so it is not subject to authorization.
+func (s *serviceImplementation) PurgeDeletedPosts(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, done := s.instrument(ctx, "purge_deleted_posts")
+	defer done()
+	return s.repository.PurgeDeletedPosts(ctx, deletedBefore)
+}
+
+func (s *serviceImplementation) ListRevisions(ctx context.Context, postID uuid.UUID,
+	request entity.ListRevisionsRequest) (*entity.ListRevisionsResponse, error) {
+	ctx, done := s.instrument(ctx, "list_revisions")
+	defer done()
+	if _, err := s.GetPost(ctx, postID); err != nil {
+		return nil, err
+	}
+	pageSize, err := pageSize(request.PageSize, defaultRevisionPageSize, maxRevisionPageSize)
+	if err != nil {
+		return nil, err
+	}
+	request.PageSize = pageSize
+	return s.repository.ListRevisions(ctx, postID, request)
+}
+
+func (s *serviceImplementation) GetRevision(ctx context.Context, postID uuid.UUID,
+	revision int64) (*entity.PostRevision, error) {
+	ctx, done := s.instrument(ctx, "get_revision")
+	defer done()
+	if _, err := s.GetPost(ctx, postID); err != nil {
+		return nil, err
+	}
+	return s.repository.GetRevision(ctx, postID, revision)
+}
+
+func (s *serviceImplementation) DiffRevisions(ctx context.Context, postID uuid.UUID,
+	fromRevision, toRevision int64) (*entity.RevisionDiff, error) {
+	ctx, done := s.instrument(ctx, "diff_revisions")
+	defer done()
+	from, err := s.GetRevision(ctx, postID, fromRevision)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repository.GetRevision(ctx, postID, toRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.RevisionDiff{
+		FromRevision:  fromRevision,
+		ToRevision:    toRevision,
+		ChangedFields: changedFields(from, to),
+		ContentDiff:   diffLines(from.Content, to.Content),
+	}, nil
+}
+
+// RestoreRevision writes the snapshot of an earlier revision back to the post,
+// which creates a new revision rather than rewinding the history.
+func (s *serviceImplementation) RestoreRevision(ctx context.Context, postID uuid.UUID,
+	request entity.RestoreRevisionRequest) (*entity.PostDetail, error) {
+	ctx, done := s.instrument(ctx, "restore_revision")
+	defer done()
+	revision, err := s.GetRevision(ctx, postID, request.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.UpdatePost(ctx, postID, entity.UpdatePostRequest{
+		Title:       &revision.Title,
+		Content:     &revision.Content,
+		Author:      &revision.Author,
+		PublishedOn: &revision.PublishedOn,
+		Tags:        revision.Tags,
+		UpdateMask: []string{entity.PostFieldTitle, entity.PostFieldContent, entity.PostFieldAuthor,
+			entity.PostFieldPublishedOn, entity.PostFieldTags},
+		ExpectedRevision: request.ExpectedRevision,
+	})
+}
+
+// pageSize returns the requested page size, defaultSize when it is not set
+// and at most maxSize.
+func pageSize(size, defaultSize, maxSize int) (int, error) {
+	switch {
+	case size < 0:
+		return 0, domainerror.InvalidArgument("page size must not be negative", domainerror.FieldViolation{
+			Field:       "page_size",
+			Description: "must not be negative",
+		})
+	case size == 0:
+		return defaultSize, nil
+	default:
+		return min(size, maxSize), nil
+	}
 }
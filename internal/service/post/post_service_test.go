@@ -5,28 +5,51 @@ import (
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/sdoshi579/cloudbees/internal/auth"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
 	"github.com/sdoshi579/cloudbees/internal/entity"
 	mockpostrepository "github.com/sdoshi579/cloudbees/internal/mockgen/repository/post"
-	"go.uber.org/zap"
+	"math/rand"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
+func newCreatePostRequest(title string) entity.CreatePostRequest {
+	return entity.CreatePostRequest{
+		Title:       title,
+		Content:     "content",
+		Author:      "author",
+		PublishedOn: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Tags:        []string{"go", "grpc"},
+	}
+}
+
 func Test_serviceImplementation_CreatePost(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mockpostrepository.NewMockRepository(ctrl)
 
-	mockRepo.EXPECT().CreatePost(gomock.Any(), entity.CreatePostRequest{
-		Title: "success post",
-	}).MaxTimes(1).Return(&entity.PostDetail{
+	mockRepo.EXPECT().CreatePost(gomock.Any(), newCreatePostRequest("success post")).MaxTimes(1).Return(&entity.PostDetail{
 		Title: "success post",
 	}, nil)
 
-	mockRepo.EXPECT().CreatePost(gomock.Any(), entity.CreatePostRequest{
-		Title: "failed post",
-	}).MaxTimes(1).Return(nil, errors.New("error in creating post"))
+	mockRepo.EXPECT().CreatePost(gomock.Any(), newCreatePostRequest("failed post")).
+		MaxTimes(1).Return(nil, errors.New("error in creating post"))
+
+	duplicateTags := newCreatePostRequest("duplicate tags")
+	duplicateTags.Tags = []string{"go", "grpc", "go"}
+	mockRepo.EXPECT().CreatePost(gomock.Any(), newCreatePostRequest("duplicate tags")).MaxTimes(1).
+		Return(&entity.PostDetail{Title: "duplicate tags"}, nil)
+
+	invalid := newCreatePostRequest(" ")
+	invalid.Author = ""
+	invalid.PublishedOn = time.Time{}
+	invalid.Tags = []string{"go", "Not A Tag"}
+
 	type args struct {
 		ctx     context.Context
 		request entity.CreatePostRequest
@@ -40,10 +63,8 @@ func Test_serviceImplementation_CreatePost(t *testing.T) {
 		{
 			name: "success in creating post",
 			args: args{
-				ctx: context.Background(),
-				request: entity.CreatePostRequest{
-					Title: "success post",
-				},
+				ctx:     context.Background(),
+				request: newCreatePostRequest("success post"),
 			},
 			want: &entity.PostDetail{
 				Title: "success post",
@@ -53,20 +74,43 @@ func Test_serviceImplementation_CreatePost(t *testing.T) {
 		{
 			name: "error in creating post",
 			args: args{
-				ctx: context.Background(),
-				request: entity.CreatePostRequest{
-					Title: "failed post",
-				},
+				ctx:     context.Background(),
+				request: newCreatePostRequest("failed post"),
 			},
 			want: nil,
 			err:  errors.New("error in creating post"),
 		},
+		{
+			name: "duplicate tags are removed",
+			args: args{
+				ctx:     context.Background(),
+				request: duplicateTags,
+			},
+			want: &entity.PostDetail{
+				Title: "duplicate tags",
+			},
+			err: nil,
+		},
+		{
+			name: "invalid post",
+			args: args{
+				ctx:     context.Background(),
+				request: invalid,
+			},
+			want: nil,
+			err: domainerror.InvalidArgument("invalid post",
+				domainerror.FieldViolation{Field: "title", Description: "is required"},
+				domainerror.FieldViolation{Field: "author", Description: "is required"},
+				domainerror.FieldViolation{Field: "published_on", Description: "is required"},
+				domainerror.FieldViolation{Field: "tags[1]",
+					Description: "must be 1-32 lowercase letters, digits or hyphens and start with a letter or digit"},
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &serviceImplementation{
 				repository: mockRepo,
-				logger:     zap.NewExample(),
 			}
 			got, err := s.CreatePost(tt.args.ctx, tt.args.request)
 			if !reflect.DeepEqual(got, tt.want) {
@@ -127,7 +171,6 @@ func Test_serviceImplementation_GetPost(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &serviceImplementation{
 				repository: mockRepo,
-				logger:     zap.NewExample(),
 			}
 			got, err := s.GetPost(tt.args.ctx, tt.args.id)
 			if !reflect.DeepEqual(got, tt.want) {
@@ -156,11 +199,13 @@ func Test_serviceImplementation_UpdatePost(t *testing.T) {
 		MaxTimes(1).Return(nil, errors.New("error in updating post"))
 
 	mockRepo.EXPECT().GetPost(gomock.Any(), postNotFoundPostID).MaxTimes(1).
-		Return(nil, errors.New("post not found or is deleted"))
+		Return(nil, domainerror.NotFound("post", postNotFoundPostID.String()))
 
 	mockRepo.EXPECT().GetPost(gomock.Any(), gomock.Any()).MaxTimes(2).
 		Return(&entity.PostDetail{}, nil)
 
+	emptyTitle := ""
+
 	type args struct {
 		ctx     context.Context
 		id      uuid.UUID
@@ -192,6 +237,17 @@ func Test_serviceImplementation_UpdatePost(t *testing.T) {
 			want: nil,
 			err:  errors.New("error in updating post"),
 		},
+		{
+			name: "invalid update",
+			args: args{
+				ctx:     context.Background(),
+				id:      successPostID,
+				request: entity.UpdatePostRequest{Title: &emptyTitle, UpdateMask: []string{entity.PostFieldTitle}},
+			},
+			want: nil,
+			err: domainerror.InvalidArgument("invalid post",
+				domainerror.FieldViolation{Field: "title", Description: "is required"}),
+		},
 		{
 			name: "post is not available",
 			args: args{
@@ -199,14 +255,13 @@ func Test_serviceImplementation_UpdatePost(t *testing.T) {
 				id:  postNotFoundPostID,
 			},
 			want: nil,
-			err:  errors.New("post is not available or is deleted"),
+			err:  domainerror.NotFound("post", postNotFoundPostID.String()),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &serviceImplementation{
 				repository: mockRepo,
-				logger:     zap.NewExample(),
 			}
 			got, err := s.UpdatePost(tt.args.ctx, tt.args.id, tt.args.request)
 			if !reflect.DeepEqual(got, tt.want) {
@@ -227,9 +282,13 @@ func Test_serviceImplementation_DeletePost(t *testing.T) {
 
 	successPostID := uuid.New()
 	failPostID := uuid.New()
-	mockRepo.EXPECT().DeletePost(gomock.Any(), successPostID).MaxTimes(1).Return(true, nil)
+	mockRepo.EXPECT().DeletePost(gomock.Any(), successPostID, gomock.Any()).MaxTimes(1).Return(nil)
+
+	mockRepo.EXPECT().DeletePost(gomock.Any(), failPostID, gomock.Any()).MaxTimes(1).Return(errors.New("error in deleting post"))
 
-	mockRepo.EXPECT().DeletePost(gomock.Any(), failPostID).MaxTimes(1).Return(false, errors.New("error in deleting post"))
+	authenticatedPostID := uuid.New()
+	mockRepo.EXPECT().DeletePost(gomock.Any(), authenticatedPostID, entity.DeletePostRequest{DeletedBy: "alice"}).
+		Times(1).Return(nil)
 
 	type args struct {
 		ctx context.Context
@@ -238,7 +297,6 @@ func Test_serviceImplementation_DeletePost(t *testing.T) {
 	tests := []struct {
 		name string
 		args args
-		want bool
 		err  error
 	}{
 		{
@@ -247,8 +305,7 @@ func Test_serviceImplementation_DeletePost(t *testing.T) {
 				ctx: context.Background(),
 				id:  successPostID,
 			},
-			want: true,
-			err:  nil,
+			err: nil,
 		},
 		{
 			name: "error in deleting post",
@@ -256,23 +313,379 @@ func Test_serviceImplementation_DeletePost(t *testing.T) {
 				ctx: context.Background(),
 				id:  failPostID,
 			},
-			want: false,
-			err:  errors.New("error in deleting post"),
+			err: errors.New("error in deleting post"),
+		},
+		{
+			name: "caller is recorded as deleted by",
+			args: args{
+				ctx: auth.NewContext(context.Background(), &auth.Principal{Subject: "alice"}),
+				id:  authenticatedPostID,
+			},
+			err: nil,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &serviceImplementation{
 				repository: mockRepo,
-				logger:     zap.NewExample(),
 			}
-			got, err := s.DeletePost(tt.args.ctx, tt.args.id)
+			err := s.DeletePost(tt.args.ctx, tt.args.id, entity.DeletePostRequest{})
 			if !reflect.DeepEqual(err, tt.err) {
 				t.Errorf("DeletePost() error got = %v, want %v", err, tt.err)
 			}
-			if got != tt.want {
-				t.Errorf("DeletePost() got = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func Test_serviceImplementation_ListPosts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockpostrepository.NewMockRepository(ctrl)
+
+	mockRepo.EXPECT().ListPosts(gomock.Any(), entity.ListPostsRequest{PageSize: defaultPageSize}).MaxTimes(1).
+		Return(&entity.ListPostsResponse{NextPageToken: "default"}, nil)
+	mockRepo.EXPECT().ListPosts(gomock.Any(), entity.ListPostsRequest{PageSize: maxPageSize, PageToken: "next"}).
+		MaxTimes(1).Return(&entity.ListPostsResponse{NextPageToken: "capped"}, nil)
+	mockRepo.EXPECT().ListPosts(gomock.Any(), entity.ListPostsRequest{PageSize: 5}).MaxTimes(1).
+		Return(nil, errors.New("error in listing posts"))
+
+	publishedAfter := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	publishedBefore := publishedAfter.AddDate(0, 1, 0)
+
+	type args struct {
+		ctx     context.Context
+		request entity.ListPostsRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		want *entity.ListPostsResponse
+		err  error
+	}{
+		{
+			name: "default page size",
+			args: args{
+				ctx:     context.Background(),
+				request: entity.ListPostsRequest{},
+			},
+			want: &entity.ListPostsResponse{NextPageToken: "default"},
+			err:  nil,
+		},
+		{
+			name: "page size is capped",
+			args: args{
+				ctx:     context.Background(),
+				request: entity.ListPostsRequest{PageSize: 1000, PageToken: "next"},
+			},
+			want: &entity.ListPostsResponse{NextPageToken: "capped"},
+			err:  nil,
+		},
+		{
+			name: "negative page size",
+			args: args{
+				ctx:     context.Background(),
+				request: entity.ListPostsRequest{PageSize: -1},
+			},
+			want: nil,
+			err: domainerror.InvalidArgument("page size must not be negative", domainerror.FieldViolation{
+				Field:       "page_size",
+				Description: "must not be negative",
+			}),
+		},
+		{
+			name: "empty publish window",
+			args: args{
+				ctx: context.Background(),
+				request: entity.ListPostsRequest{Filter: entity.PostFilter{
+					PublishedAfter:  &publishedBefore,
+					PublishedBefore: &publishedAfter,
+				}},
+			},
+			want: nil,
+			err: domainerror.InvalidArgument("published_after must be before published_before",
+				domainerror.FieldViolation{
+					Field:       "filter.published_after",
+					Description: "must be before filter.published_before",
+				}),
+		},
+		{
+			name: "error in listing posts",
+			args: args{
+				ctx:     context.Background(),
+				request: entity.ListPostsRequest{PageSize: 5},
+			},
+			want: nil,
+			err:  errors.New("error in listing posts"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &serviceImplementation{
+				repository: mockRepo,
+			}
+			got, err := s.ListPosts(tt.args.ctx, tt.args.request)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListPosts() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("ListPosts() error got = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func Test_diffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []entity.DiffLine
+	}{
+		{
+			name: "identical",
+			from: "a\nb",
+			to:   "a\nb",
+			want: []entity.DiffLine{
+				{Operation: entity.DiffEqual, Text: "a"},
+				{Operation: entity.DiffEqual, Text: "b"},
+			},
+		},
+		{
+			name: "changed line",
+			from: "a\nb\nc",
+			to:   "a\nx\nc\nd",
+			want: []entity.DiffLine{
+				{Operation: entity.DiffEqual, Text: "a"},
+				{Operation: entity.DiffDelete, Text: "b"},
+				{Operation: entity.DiffInsert, Text: "x"},
+				{Operation: entity.DiffEqual, Text: "c"},
+				{Operation: entity.DiffInsert, Text: "d"},
+			},
+		},
+		{
+			name: "from empty",
+			from: "",
+			to:   "a",
+			want: []entity.DiffLine{
+				{Operation: entity.DiffInsert, Text: "a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLines() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// lcsLength is the length of the longest common subsequence of a and b.
+func lcsLength(a, b []string) int {
+	previous, current := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				current[j+1] = previous[j] + 1
+			} else {
+				current[j+1] = max(previous[j+1], current[j])
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func Test_diffLines_minimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomLines := func() string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + random.Intn(4)))
+		}
+		return strings.Join(lines, "\n")
+	}
+	for i := 0; i < 500; i++ {
+		from, to := randomLines(), randomLines()
+		diff := diffLines(from, to)
+
+		var gotFrom, gotTo []string
+		equal := 0
+		for _, line := range diff {
+			if line.Operation != entity.DiffInsert {
+				gotFrom = append(gotFrom, line.Text)
+			}
+			if line.Operation != entity.DiffDelete {
+				gotTo = append(gotTo, line.Text)
+			}
+			if line.Operation == entity.DiffEqual {
+				equal++
+			}
+		}
+		if strings.Join(gotFrom, "\n") != from || strings.Join(gotTo, "\n") != to {
+			t.Fatalf("diffLines(%q, %q) = %v, does not turn one into the other", from, to, diff)
+		}
+		if want := lcsLength(splitLines(from), splitLines(to)); equal != want {
+			t.Fatalf("diffLines(%q, %q) keeps %d lines, want %d", from, to, equal, want)
+		}
+	}
+}
+
+func Test_diffLines_large(t *testing.T) {
+	distinct := func(prefix string) string {
+		lines := make([]string, maxContentLength/10)
+		for i := range lines {
+			lines[i] = prefix + strconv.Itoa(i)
+		}
+		return strings.Join(lines, "\n")
+	}
+	newlines := strings.Repeat("\n", maxContentLength)
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		wantEqual int
+	}{
+		{name: "unrelated contents", from: distinct("a"), to: distinct("b"), wantEqual: 0},
+		{name: "newlines", from: newlines, to: newlines[1:] + "x", wantEqual: maxContentLength - 1},
+		{name: "edits spread out", from: distinct("a"), to: strings.ReplaceAll(distinct("a"), "5\n", "x\n"),
+			wantEqual: maxContentLength/10 - maxContentLength/100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan []entity.DiffLine, 1)
+			go func() { done <- diffLines(tt.from, tt.to) }()
+			select {
+			case diff := <-done:
+				equal := 0
+				for _, line := range diff {
+					if line.Operation == entity.DiffEqual {
+						equal++
+					}
+				}
+				if equal != tt.wantEqual {
+					t.Errorf("diffLines() keeps %d lines, want %d", equal, tt.wantEqual)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("diffLines() did not finish within 10s")
 			}
 		})
 	}
 }
+
+func Test_serviceImplementation_DiffRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockpostrepository.NewMockRepository(ctrl)
+
+	postID := uuid.New()
+	publishedOn := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetPost(gomock.Any(), postID).AnyTimes().Return(&entity.PostDetail{ID: postID}, nil)
+	mockRepo.EXPECT().GetRevision(gomock.Any(), postID, int64(1)).AnyTimes().Return(&entity.PostRevision{
+		PostID: postID, Revision: 1, Title: "title", Content: "a\nb", Author: "author",
+		PublishedOn: publishedOn, Tags: []string{"go"},
+	}, nil)
+	mockRepo.EXPECT().GetRevision(gomock.Any(), postID, int64(2)).AnyTimes().Return(&entity.PostRevision{
+		PostID: postID, Revision: 2, Title: "new title", Content: "a\nc", Author: "author",
+		PublishedOn: publishedOn, Tags: []string{"go"},
+	}, nil)
+	mockRepo.EXPECT().GetRevision(gomock.Any(), postID, int64(3)).AnyTimes().
+		Return(nil, domainerror.NotFound("post revision", postID.String()+"@3"))
+
+	s := &serviceImplementation{
+		repository: mockRepo,
+	}
+
+	got, err := s.DiffRevisions(context.Background(), postID, 1, 2)
+	if err != nil {
+		t.Fatalf("DiffRevisions() error = %v", err)
+	}
+	want := &entity.RevisionDiff{
+		FromRevision:  1,
+		ToRevision:    2,
+		ChangedFields: []string{entity.PostFieldTitle, entity.PostFieldContent},
+		ContentDiff: []entity.DiffLine{
+			{Operation: entity.DiffEqual, Text: "a"},
+			{Operation: entity.DiffDelete, Text: "b"},
+			{Operation: entity.DiffInsert, Text: "c"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRevisions() got = %v, want %v", got, want)
+	}
+
+	_, err = s.DiffRevisions(context.Background(), postID, 1, 3)
+	if domainerror.KindOf(err) != domainerror.KindNotFound {
+		t.Errorf("DiffRevisions() error = %v, want not found", err)
+	}
+}
+
+func Test_serviceImplementation_ListRevisions(t *testing.T) {
+	postID := uuid.New()
+	tests := []struct {
+		name         string
+		pageSize     int
+		wantPageSize int
+		wantErr      bool
+	}{
+		{name: "default page size", pageSize: 0, wantPageSize: defaultRevisionPageSize},
+		{name: "capped page size", pageSize: 50, wantPageSize: maxRevisionPageSize},
+		{name: "negative page size", pageSize: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mockpostrepository.NewMockRepository(ctrl)
+			mockRepo.EXPECT().GetPost(gomock.Any(), postID).Return(&entity.PostDetail{ID: postID}, nil)
+			if !tt.wantErr {
+				mockRepo.EXPECT().ListRevisions(gomock.Any(), postID,
+					entity.ListRevisionsRequest{PageSize: tt.wantPageSize, PageToken: "token"}).
+					Return(&entity.ListRevisionsResponse{}, nil)
+			}
+			s := &serviceImplementation{repository: mockRepo}
+
+			_, err := s.ListRevisions(context.Background(), postID,
+				entity.ListRevisionsRequest{PageSize: tt.pageSize, PageToken: "token"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListRevisions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_serviceImplementation_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockpostrepository.NewMockRepository(ctrl)
+
+	postID := uuid.New()
+	publishedOn := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetPost(gomock.Any(), postID).AnyTimes().Return(&entity.PostDetail{ID: postID}, nil)
+	mockRepo.EXPECT().GetRevision(gomock.Any(), postID, int64(1)).Return(&entity.PostRevision{
+		PostID: postID, Revision: 1, Title: "title", Content: "content", Author: "author",
+		PublishedOn: publishedOn, Tags: []string{"go"},
+	}, nil)
+	mockRepo.EXPECT().UpdatePost(gomock.Any(), postID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error) {
+			if *request.Title != "title" || *request.Content != "content" || request.ExpectedRevision != 4 ||
+				len(request.UpdateMask) != 5 {
+				t.Errorf("UpdatePost() request = %+v, want snapshot of revision 1", request)
+			}
+			return &entity.PostDetail{ID: postID, Title: *request.Title, Revision: 5}, nil
+		})
+
+	s := &serviceImplementation{
+		repository: mockRepo,
+	}
+	got, err := s.RestoreRevision(context.Background(), postID,
+		entity.RestoreRevisionRequest{Revision: 1, ExpectedRevision: 4})
+	if err != nil {
+		t.Fatalf("RestoreRevision() error = %v", err)
+	}
+	if got.Revision != 5 {
+		t.Errorf("RestoreRevision() revision = %d, want 5", got.Revision)
+	}
+}
@@ -0,0 +1,23 @@
+package post
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracerProvider records every service call as a span.
+func WithTracerProvider(provider trace.TracerProvider) ServiceConfiguration {
+	return func(r *serviceImplementation) {
+		r.tracer = provider.Tracer("github.com/sdoshi579/cloudbees/internal/service/post")
+	}
+}
+
+// instrument starts the span of an operation, it is called at the top of
+// every operation with the returned function deferred.
+func (s *serviceImplementation) instrument(ctx context.Context, operation string) (context.Context, func()) {
+	if s.tracer == nil {
+		return ctx, func() {}
+	}
+	ctx, span := s.tracer.Start(ctx, "service.post."+operation)
+	return ctx, func() { span.End() }
+}
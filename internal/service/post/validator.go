@@ -0,0 +1,127 @@
+package post
+
+import (
+	"fmt"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxTitleLength   = 200
+	maxAuthorLength  = 100
+	maxContentLength = 100000
+	maxTags          = 20
+	// published_on may be scheduled ahead, but not absurdly far
+	maxPublishAhead = 5 * 365 * 24 * time.Hour
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// validator collects field violations so a client gets every problem with a
+// request at once instead of fixing them one round trip at a time.
+type validator struct {
+	violations []domainerror.FieldViolation
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.violations = append(v.violations, domainerror.FieldViolation{
+		Field:       field,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) requiredString(field, value string, maxLength int) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+		return
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		v.add(field, "must be at most %d characters", maxLength)
+	}
+}
+
+func (v *validator) publishedOn(value time.Time, now time.Time) {
+	switch {
+	case value.IsZero():
+		v.add("published_on", "is required")
+	case !value.After(time.Unix(0, 0)):
+		v.add("published_on", "must be after 1970-01-01")
+	case value.After(now.Add(maxPublishAhead)):
+		v.add("published_on", "must not be more than 5 years in the future")
+	}
+}
+
+// tags validates tag format and returns the tags with duplicates removed.
+func (v *validator) tags(tags []string) []string {
+	if len(tags) == 0 {
+		return tags
+	}
+	if len(tags) > maxTags {
+		v.add("tags", "must have at most %d tags", maxTags)
+		return tags
+	}
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if !tagPattern.MatchString(tag) {
+			v.add(fmt.Sprintf("tags[%d]", i),
+				"must be 1-32 lowercase letters, digits or hyphens and start with a letter or digit")
+			continue
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		unique = append(unique, tag)
+	}
+	return unique
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return domainerror.InvalidArgument("invalid post", v.violations...)
+}
+
+func validateCreatePost(request *entity.CreatePostRequest, now time.Time) error {
+	v := &validator{}
+	v.requiredString("title", request.Title, maxTitleLength)
+	v.requiredString("content", request.Content, maxContentLength)
+	v.requiredString("author", request.Author, maxAuthorLength)
+	v.publishedOn(request.PublishedOn, now)
+	request.Tags = v.tags(request.Tags)
+	return v.err()
+}
+
+func validateUpdatePost(request *entity.UpdatePostRequest, now time.Time) error {
+	v := &validator{}
+	// unknown paths are rejected by the repository which owns the field mapping
+	for _, path := range request.UpdateMask {
+		switch path {
+		case entity.PostFieldTitle:
+			v.requiredString("title", valueOrZero(request.Title), maxTitleLength)
+		case entity.PostFieldContent:
+			v.requiredString("content", valueOrZero(request.Content), maxContentLength)
+		case entity.PostFieldAuthor:
+			v.requiredString("author", valueOrZero(request.Author), maxAuthorLength)
+		case entity.PostFieldPublishedOn:
+			v.publishedOn(valueOrZero(request.PublishedOn), now)
+		case entity.PostFieldTags:
+			request.Tags = v.tags(request.Tags)
+		}
+	}
+	return v.err()
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
@@ -0,0 +1,129 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"entgo.io/ent/dialect"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// driver wraps an ent driver and records every statement it runs as a
+// client span. Statements are recorded with their placeholders, the
+// arguments are left out as they hold the content of posts.
+type driver struct {
+	dialect.Driver
+	tracer trace.Tracer
+	system attribute.KeyValue
+}
+
+// NewDriver returns drv recording its statements as spans of provider.
+func NewDriver(drv dialect.Driver, provider trace.TracerProvider) dialect.Driver {
+	return &driver{Driver: drv, tracer: provider.Tracer(instrumentation), system: dbSystem(drv.Dialect())}
+}
+
+func dbSystem(name string) attribute.KeyValue {
+	switch name {
+	case dialect.Postgres:
+		return semconv.DBSystemPostgreSQL
+	case dialect.MySQL:
+		return semconv.DBSystemMySQL
+	default:
+		return semconv.DBSystemSqlite
+	}
+}
+
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := d.start(ctx, query)
+	err := d.Driver.Exec(ctx, query, args, v)
+	endStatement(span, err)
+	return err
+}
+
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := d.start(ctx, query)
+	err := d.Driver.Query(ctx, query, args, v)
+	endStatement(span, err)
+	return err
+}
+
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a transaction with options when the wrapped driver
+// supports them, like the ent sql driver does.
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	ctx, span := d.start(ctx, "BEGIN")
+	var (
+		tx  dialect.Tx
+		err error
+	)
+	if beginner, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	}); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = d.Driver.Tx(ctx)
+	}
+	endStatement(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &driverTx{Tx: tx, driver: d, ctx: ctx}, nil
+}
+
+func (d *driver) start(ctx context.Context, statement string) (context.Context, trace.Span) {
+	operation, _, _ := strings.Cut(strings.TrimSpace(statement), " ")
+	operation = strings.ToUpper(operation)
+	return d.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		d.system, semconv.DBOperation(operation), semconv.DBStatement(statement),
+	))
+}
+
+func endStatement(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// driverTx records the statements of a transaction, and its commit or
+// rollback, in the trace the transaction was started in.
+type driverTx struct {
+	dialect.Tx
+	driver *driver
+	ctx    context.Context
+}
+
+func (t *driverTx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := t.driver.start(ctx, query)
+	err := t.Tx.Exec(ctx, query, args, v)
+	endStatement(span, err)
+	return err
+}
+
+func (t *driverTx) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := t.driver.start(ctx, query)
+	err := t.Tx.Query(ctx, query, args, v)
+	endStatement(span, err)
+	return err
+}
+
+func (t *driverTx) Commit() error {
+	_, span := t.driver.start(t.ctx, "COMMIT")
+	err := t.Tx.Commit()
+	endStatement(span, err)
+	return err
+}
+
+func (t *driverTx) Rollback() error {
+	_, span := t.driver.start(t.ctx, "ROLLBACK")
+	err := t.Tx.Rollback()
+	endStatement(span, err)
+	return err
+}
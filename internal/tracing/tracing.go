@@ -0,0 +1,76 @@
+// Package tracing records OpenTelemetry spans for the calls the server
+// answers, down to the SQL statements they run, and exports them to stdout
+// or to an OTLP collector. Trace context is propagated with the W3C
+// traceparent and tracestate headers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"os"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// serviceName is the service.name resource attribute of every span.
+const serviceName = "cloudbees"
+
+// instrumentation names the tracers of this package.
+const instrumentation = "github.com/sdoshi579/cloudbees/internal/tracing"
+
+type Config struct {
+	// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP.
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	// Insecure sends spans to the collector without TLS.
+	Insecure bool
+	// SampleRatio is the fraction of traces recorded, unless the caller
+	// already decided whether its trace is sampled.
+	SampleRatio float64
+}
+
+// NewTracerProvider returns a provider exporting spans in batches as config
+// describes. It has to be shut down to flush the last batch.
+func NewTracerProvider(ctx context.Context, config Config) (*sdktrace.TracerProvider, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch config.Exporter {
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", config.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", config.Exporter, err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	), nil
+}
+
+// Propagator reads and writes the W3C trace context headers.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.TraceContext{}
+}
@@ -0,0 +1,138 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"testing"
+)
+
+func newRecorder() (*tracetest.SpanRecorder, trace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	return recorder, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestInterceptor_Unary(t *testing.T) {
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		err        error
+		wantParent bool
+		wantStatus otelcodes.Code
+	}{
+		{name: "continues the trace of the caller", md: metadata.Pairs("traceparent", traceparent),
+			wantParent: true, wantStatus: otelcodes.Unset},
+		{name: "starts a trace", wantStatus: otelcodes.Unset},
+		{name: "client errors are not failures", err: status.Error(codes.NotFound, "post not found"),
+			wantStatus: otelcodes.Unset},
+		{name: "server errors are failures", err: status.Error(codes.Internal, "database is locked"),
+			wantStatus: otelcodes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, provider := newRecorder()
+			interceptor := NewInterceptor(provider).Unary()
+			var handlerSpan trace.SpanContext
+			interceptor(metadata.NewIncomingContext(context.Background(), tt.md), nil,
+				&grpc.UnaryServerInfo{FullMethod: "/post.v1.PostService/Get"},
+				func(ctx context.Context, req any) (any, error) {
+					handlerSpan = trace.SpanContextFromContext(ctx)
+					return nil, tt.err
+				})
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != "post.v1.PostService/Get" || span.SpanKind() != trace.SpanKindServer {
+				t.Errorf("span = %s %s, want a server span named post.v1.PostService/Get", span.SpanKind(), span.Name())
+			}
+			if !handlerSpan.Equal(span.SpanContext()) {
+				t.Error("the handler does not run in the span of the call")
+			}
+			if got := span.Parent().IsRemote(); got != tt.wantParent {
+				t.Errorf("span has a remote parent = %v, want %v", got, tt.wantParent)
+			}
+			if tt.wantParent && span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("trace id = %s, want the one of the traceparent", span.SpanContext().TraceID())
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			if got, want := attributeValue(span, semconv.RPCGRPCStatusCodeKey).AsInt64(), int64(status.Code(tt.err)); got != want {
+				t.Errorf("rpc.grpc.status_code = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDriver(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	recorder, provider := newRecorder()
+	drv := NewDriver(entsql.OpenDB(dialect.SQLite, db), provider)
+
+	if err := drv.Exec(ctx, "CREATE TABLE posts (title text)", []any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Exec(ctx, "INSERT INTO posts (title) VALUES (?)", []any{"secret title"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	var rows entsql.Rows
+	if err := drv.Query(ctx, "SELECT title FROM missing", []any{}, &rows); err == nil {
+		t.Fatal("Query() on a missing table succeeded")
+	}
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+		if got := attributeValue(span, semconv.DBSystemKey).AsString(); got != "sqlite" {
+			t.Errorf("%s db.system = %q, want sqlite", span.Name(), got)
+		}
+	}
+	if want := []string{"CREATE", "BEGIN", "INSERT", "COMMIT", "SELECT"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("spans = %v, want %v", names, want)
+	}
+	insert := recorder.Ended()[2]
+	if got := attributeValue(insert, semconv.DBStatementKey).AsString(); got != "INSERT INTO posts (title) VALUES (?)" {
+		t.Errorf("db.statement = %q, want the statement without its arguments", got)
+	}
+	if failed := recorder.Ended()[4]; failed.Status().Code != otelcodes.Error {
+		t.Errorf("failed statement status = %v, want %v", failed.Status().Code, otelcodes.Error)
+	}
+}
@@ -0,0 +1,98 @@
+package apikey
+
+import (
+	"context"
+	"github.com/google/uuid"
+	apikeyv1 "github.com/sdoshi579/cloudbees/gen/apikey/v1"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/internal/service/apikey"
+	"github.com/sdoshi579/cloudbees/rpc/rpcerror"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type RPCImplementation struct {
+	apikeyv1.UnimplementedApiKeyServiceServer
+	service apikey.Service
+	logger  *zap.Logger
+}
+
+func NewRPCImplementation(apiKeyService apikey.Service, logger *zap.Logger) *RPCImplementation {
+	return &RPCImplementation{
+		service: apiKeyService,
+		logger:  logger,
+	}
+}
+
+func (r *RPCImplementation) CreateApiKey(ctx context.Context,
+	request *apikeyv1.CreateApiKeyRequest) (*apikeyv1.CreateApiKeyResponse, error) {
+	entityRequest := entity.CreateAPIKeyRequest{
+		Name:   request.Name,
+		Scopes: request.Scopes,
+	}
+	if request.ExpiresAt != nil {
+		expiresAt := request.ExpiresAt.AsTime()
+		entityRequest.ExpiresAt = &expiresAt
+	}
+
+	resp, err := r.service.CreateAPIKey(ctx, entityRequest)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	return &apikeyv1.CreateApiKeyResponse{
+		ApiKey: toAPIKeyMessage(&resp.APIKey),
+		Key:    resp.Key,
+	}, nil
+}
+func (r *RPCImplementation) ListApiKeys(ctx context.Context,
+	request *apikeyv1.ListApiKeysRequest) (*apikeyv1.ListApiKeysResponse, error) {
+	resp, err := r.service.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	keys := make([]*apikeyv1.ApiKey, 0, len(resp))
+	for _, key := range resp {
+		keys = append(keys, toAPIKeyMessage(key))
+	}
+	return &apikeyv1.ListApiKeysResponse{ApiKeys: keys}, nil
+}
+func (r *RPCImplementation) RevokeApiKey(ctx context.Context,
+	request *apikeyv1.RevokeApiKeyRequest) (*apikeyv1.RevokeApiKeyResponse, error) {
+	id, err := uuid.Parse(request.Id)
+	if err != nil {
+		return nil, rpcerror.ToStatus(domainerror.InvalidArgument("invalid api key id", domainerror.FieldViolation{
+			Field:       "id",
+			Description: "must be a valid UUID",
+		}).Wrap(err))
+	}
+
+	resp, err := r.service.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	return &apikeyv1.RevokeApiKeyResponse{ApiKey: toAPIKeyMessage(resp)}, nil
+}
+
+func toAPIKeyMessage(key *entity.APIKey) *apikeyv1.ApiKey {
+	return &apikeyv1.ApiKey{
+		Id:        key.ID.String(),
+		Name:      key.Name,
+		KeyPrefix: key.KeyPrefix,
+		Scopes:    key.Scopes,
+		CreatedBy: key.CreatedBy,
+		ExpiresAt: optionalTimestamp(key.ExpiresAt),
+		RevokedAt: optionalTimestamp(key.RevokedAt),
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,51 @@
+package apikey
+
+import (
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	apikeyv1 "github.com/sdoshi579/cloudbees/gen/apikey/v1"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mock_apikey "github.com/sdoshi579/cloudbees/internal/mockgen/service/apikey"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestRPCImplementation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mock_apikey.NewMockService(ctrl)
+	r := NewRPCImplementation(service, zap.NewNop())
+	ctx := context.Background()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := entity.APIKey{ID: uuid.New(), Name: "importer", KeyPrefix: "cbk_abcdefgh", Scopes: []string{"author"},
+		ExpiresAt: &expiresAt}
+	service.EXPECT().CreateAPIKey(ctx, entity.CreateAPIKeyRequest{Name: "importer", Scopes: []string{"author"},
+		ExpiresAt: &expiresAt}).Return(&entity.CreatedAPIKey{APIKey: key, Key: "cbk_abcdefghsecret"}, nil)
+
+	created, err := r.CreateApiKey(ctx, &apikeyv1.CreateApiKeyRequest{Name: "importer", Scopes: []string{"author"},
+		ExpiresAt: timestamppb.New(expiresAt)})
+	if err != nil {
+		t.Fatalf("CreateApiKey() error = %v", err)
+	}
+	if created.Key != "cbk_abcdefghsecret" || created.ApiKey.Id != key.ID.String() ||
+		!created.ApiKey.ExpiresAt.AsTime().Equal(expiresAt) || created.ApiKey.RevokedAt != nil {
+		t.Errorf("CreateApiKey() = %v", created)
+	}
+
+	_, err = r.RevokeApiKey(ctx, &apikeyv1.RevokeApiKeyRequest{Id: "importer"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RevokeApiKey() error = %v, want %v", err, codes.InvalidArgument)
+	}
+
+	service.EXPECT().ListAPIKeys(ctx).Return(nil, domainerror.PermissionDenied("the admin role is required"))
+	_, err = r.ListApiKeys(ctx, &apikeyv1.ListApiKeysRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ListApiKeys() error = %v, want %v", err, codes.PermissionDenied)
+	}
+}
@@ -0,0 +1,37 @@
+package apikey
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	apikeyv1 "github.com/sdoshi579/cloudbees/gen/apikey/v1"
+	"github.com/sdoshi579/cloudbees/gen/apikey/v1/apikeyv1connect"
+	"github.com/sdoshi579/cloudbees/rpc/connectbridge"
+	"google.golang.org/grpc"
+)
+
+// ConnectHandler serves the API key RPCs over the Connect, gRPC and gRPC-Web
+// protocols with the same implementation and interceptors as the grpc-go
+// server.
+type ConnectHandler struct {
+	server      apikeyv1.ApiKeyServiceServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var _ apikeyv1connect.ApiKeyServiceHandler = (*ConnectHandler)(nil)
+
+func NewConnectHandler(server apikeyv1.ApiKeyServiceServer, interceptors ...grpc.UnaryServerInterceptor) *ConnectHandler {
+	return &ConnectHandler{
+		server:      server,
+		interceptor: connectbridge.ChainUnaryInterceptors(interceptors...),
+	}
+}
+
+func (h *ConnectHandler) CreateApiKey(ctx context.Context, request *connect.Request[apikeyv1.CreateApiKeyRequest]) (*connect.Response[apikeyv1.CreateApiKeyResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.CreateApiKey)
+}
+func (h *ConnectHandler) ListApiKeys(ctx context.Context, request *connect.Request[apikeyv1.ListApiKeysRequest]) (*connect.Response[apikeyv1.ListApiKeysResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.ListApiKeys)
+}
+func (h *ConnectHandler) RevokeApiKey(ctx context.Context, request *connect.Request[apikeyv1.RevokeApiKeyRequest]) (*connect.Response[apikeyv1.RevokeApiKeyResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.RevokeApiKey)
+}
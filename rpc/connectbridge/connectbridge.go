@@ -0,0 +1,189 @@
+// Package connectbridge serves gRPC service implementations through
+// connect-go handlers. Requests arriving over the Connect, gRPC or gRPC-Web
+// protocols look to the implementation and its unary interceptors exactly
+// like requests to the grpc-go server: headers arrive as incoming metadata,
+// the TLS connection state arrives as peer credentials.TLSInfo when the
+// handlers are wrapped with Handler, grpc.SetHeader and grpc.SetTrailer work
+// and status errors keep their code and details.
+package connectbridge
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	"crypto/tls"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ChainUnaryInterceptors combines interceptors into one the same way
+// grpc.ChainUnaryInterceptor does, the first one being the outermost.
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+type tlsStateKey struct{}
+
+// Handler passes the TLS connection state of requests to next on to Unary,
+// which connect does not expose itself.
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			r = r.WithContext(context.WithValue(r.Context(), tlsStateKey{}, r.TLS))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Unary answers request by calling handler, a method of a gRPC service
+// implementation, through interceptor when it is not nil.
+func Unary[Req, Res any](ctx context.Context, request *connect.Request[Req], interceptor grpc.UnaryServerInterceptor,
+	handler func(context.Context, *Req) (*Res, error)) (*connect.Response[Res], error) {
+
+	procedure := request.Spec().Procedure
+	md, err := incomingMetadata(request.Header())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	ctx = metadata.NewIncomingContext(ctx, md)
+	p := &peer.Peer{Addr: peerAddr(request.Peer().Addr)}
+	if state, ok := ctx.Value(tlsStateKey{}).(*tls.ConnectionState); ok {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *state,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+	ctx = peer.NewContext(ctx, p)
+	stream := &transportStream{method: procedure}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	call := func(ctx context.Context, req any) (any, error) {
+		return handler(ctx, req.(*Req))
+	}
+	var res any
+	if interceptor != nil {
+		res, err = interceptor(ctx, request.Msg, &grpc.UnaryServerInfo{FullMethod: procedure}, call)
+	} else {
+		res, err = call(ctx, request.Msg)
+	}
+
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if err != nil {
+		connectErr := toConnectError(err)
+		appendMetadata(connectErr.Meta(), stream.header)
+		appendMetadata(connectErr.Meta(), stream.trailer)
+		return nil, connectErr
+	}
+	response := connect.NewResponse(res.(*Res))
+	appendMetadata(response.Header(), stream.header)
+	appendMetadata(response.Trailer(), stream.trailer)
+	return response, nil
+}
+
+// incomingMetadata converts request headers into gRPC metadata, decoding
+// binary -bin headers the way grpc-go does.
+func incomingMetadata(header http.Header) (metadata.MD, error) {
+	md := make(metadata.MD, len(header))
+	for key, values := range header {
+		key = strings.ToLower(key)
+		if !strings.HasSuffix(key, "-bin") {
+			md[key] = append(md[key], values...)
+			continue
+		}
+		for _, value := range values {
+			decoded, err := connect.DecodeBinaryHeader(value)
+			if err != nil {
+				return nil, errors.New("malformed binary header " + key)
+			}
+			md[key] = append(md[key], string(decoded))
+		}
+	}
+	return md, nil
+}
+
+func appendMetadata(header http.Header, md metadata.MD) {
+	for key, values := range md {
+		for _, value := range values {
+			if strings.HasSuffix(key, "-bin") {
+				value = connect.EncodeBinaryHeader([]byte(value))
+			}
+			header.Add(key, value)
+		}
+	}
+}
+
+// toConnectError converts err the way grpc-go reports handler errors: status
+// errors keep their code, message and details, context errors become
+// CANCELED or DEADLINE_EXCEEDED and anything else is UNKNOWN.
+func toConnectError(err error) *connect.Error {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.FromContextError(err)
+	}
+	connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Proto().GetDetails() {
+		if errorDetail, err := connect.NewErrorDetail(detail); err == nil {
+			connectErr.AddDetail(errorDetail)
+		}
+	}
+	return connectErr
+}
+
+// transportStream collects the metadata the handler sends with
+// grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer.
+type transportStream struct {
+	method string
+
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *transportStream) Method() string {
+	return s.method
+}
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// peerAddr is the remote address connect reports as a string.
+type peerAddr string
+
+func (a peerAddr) Network() string {
+	return "tcp"
+}
+
+func (a peerAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,107 @@
+package connectbridge
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	var order []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			order = append(order, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	chain := ChainUnaryInterceptors(record("first"), record("second"))
+
+	res, err := chain(context.Background(), "req", &grpc.UnaryServerInfo{FullMethod: "/m"},
+		func(ctx context.Context, req any) (any, error) {
+			order = append(order, "handler")
+			return req, nil
+		})
+	if err != nil || res != "req" {
+		t.Fatalf("chain() = %v, %v", res, err)
+	}
+	if want := []string{"first /m", "second /m", "handler"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("call order = %v, want %v", order, want)
+	}
+}
+
+func TestToConnectError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want connect.Code
+	}{
+		{name: "status", err: status.Error(codes.NotFound, "missing"), want: connect.CodeNotFound},
+		{name: "canceled", err: context.Canceled, want: connect.CodeCanceled},
+		{name: "deadline", err: context.DeadlineExceeded, want: connect.CodeDeadlineExceeded},
+		{name: "plain error", err: errors.New("boom"), want: connect.CodeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := toConnectError(tt.err).Code(); got != tt.want {
+				t.Errorf("toConnectError(%v) code = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncomingMetadata(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer token")
+	header.Set("X-Trace-Bin", connect.EncodeBinaryHeader([]byte{0, 1}))
+
+	md, err := incomingMetadata(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("authorization = %v, want [Bearer token]", got)
+	}
+	if got := md.Get("x-trace-bin"); len(got) != 1 || got[0] != "\x00\x01" {
+		t.Errorf("x-trace-bin = %q, want decoded bytes", got)
+	}
+}
+
+func TestUnary_tlsState(t *testing.T) {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "client"}}
+	request := httptest.NewRequest(http.MethodPost, "https://localhost/post.v1.PostService/Get", nil)
+	request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{certificate}}}
+
+	var authInfo credentials.AuthInfo
+	Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := Unary(r.Context(), connect.NewRequest(&struct{}{}), nil,
+			func(ctx context.Context, req *struct{}) (*struct{}, error) {
+				if p, ok := peer.FromContext(ctx); ok {
+					authInfo = p.AuthInfo
+				}
+				return req, nil
+			})
+		if err != nil {
+			t.Errorf("Unary() error = %v", err)
+		}
+	})).ServeHTTP(httptest.NewRecorder(), request)
+
+	tlsInfo, ok := authInfo.(credentials.TLSInfo)
+	if !ok {
+		t.Fatalf("peer auth info = %#v, want credentials.TLSInfo", authInfo)
+	}
+	if chains := tlsInfo.State.VerifiedChains; len(chains) != 1 || chains[0][0] != certificate {
+		t.Errorf("verified chains = %v, want the client certificate", chains)
+	}
+}
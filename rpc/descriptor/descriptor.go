@@ -0,0 +1,66 @@
+// Package descriptor serves the compiled protobuf descriptors of the API so
+// dynamic clients can build requests without the .proto sources.
+package descriptor
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"net/http"
+	"strings"
+)
+
+// FileDescriptorSet returns files together with everything they import,
+// every file listed after its dependencies.
+func FileDescriptorSet(files ...protoreflect.FileDescriptor) *descriptorpb.FileDescriptorSet {
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range files {
+		add(file)
+	}
+	return set
+}
+
+// Handler serves the FileDescriptorSet of files in binary protobuf encoding,
+// or as JSON when the request asks for application/json or ?format=json.
+func Handler(files ...protoreflect.FileDescriptor) http.Handler {
+	set := FileDescriptorSet(files...)
+	binary, err := proto.Marshal(set)
+	if err != nil {
+		panic("descriptor: marshalling file descriptor set: " + err.Error())
+	}
+	json, err := protojson.MarshalOptions{Indent: "  "}.Marshal(set)
+	if err != nil {
+		panic("descriptor: marshalling file descriptor set: " + err.Error())
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(json)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Header().Set("Content-Disposition", `attachment; filename="descriptors.binpb"`)
+		w.Write(binary)
+	})
+}
@@ -0,0 +1,72 @@
+package descriptor
+
+import (
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFileDescriptorSet(t *testing.T) {
+	set := FileDescriptorSet(postv1.File_post_v1_post_proto)
+
+	var names []string
+	for _, file := range set.File {
+		names = append(names, file.GetName())
+	}
+	if names[len(names)-1] != "post/v1/post.proto" {
+		t.Errorf("FileDescriptorSet() files = %v, want post/v1/post.proto after its imports", names)
+	}
+	// the set must resolve on its own, without the global registry
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		t.Fatalf("NewFiles() error = %v", err)
+	}
+	if _, err := files.FindDescriptorByName("post.v1.PostService"); err != nil {
+		t.Errorf("FindDescriptorByName() error = %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(postv1.File_post_v1_post_proto)
+
+	tests := []struct {
+		name      string
+		target    string
+		accept    string
+		unmarshal func([]byte, proto.Message) error
+	}{
+		{name: "binary", target: "/descriptors", unmarshal: proto.Unmarshal},
+		{name: "json query", target: "/descriptors?format=json", unmarshal: protojson.Unmarshal},
+		{name: "json accept", target: "/descriptors", accept: "application/json", unmarshal: protojson.Unmarshal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			request.Header.Set("Accept", tt.accept)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d", tt.target, recorder.Code)
+			}
+			var set descriptorpb.FileDescriptorSet
+			if err := tt.unmarshal(recorder.Body.Bytes(), &set); err != nil {
+				t.Fatalf("unmarshal error = %v", err)
+			}
+			if !proto.Equal(&set, FileDescriptorSet(postv1.File_post_v1_post_proto)) {
+				t.Error("served descriptor set differs from FileDescriptorSet()")
+			}
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/descriptors", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,68 @@
+// Package gateway serves the post and API key APIs as REST/JSON. Requests
+// are transcoded according to the google.api.http annotations in the protos
+// and forwarded to the gRPC server, so they go through the same server
+// options as native gRPC calls.
+package gateway
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	apikeyv1 "github.com/sdoshi579/cloudbees/gen/apikey/v1"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Prefix is the path every REST endpoint lives under.
+const Prefix = "/v1/"
+
+// Dial connects to the gRPC server listening on addr. A wildcard address
+// such as the one of ":8080" is dialed on loopback. The connection is
+// plaintext unless options set transport credentials.
+func Dial(addr net.Addr, options ...grpc.DialOption) (*grpc.ClientConn, error) {
+	target := addr.String()
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok && tcpAddr.IP.IsUnspecified() {
+		target = net.JoinHostPort("127.0.0.1", strconv.Itoa(tcpAddr.Port))
+	}
+	options = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, options...)
+	return grpc.NewClient(target, options...)
+}
+
+// NewHandler returns a handler serving the REST endpoints by calling the
+// gRPC server on conn.
+func NewHandler(ctx context.Context, conn grpc.ClientConnInterface) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader))
+	if err := postv1.RegisterPostServiceHandlerClient(ctx, mux, postv1.NewPostServiceClient(conn)); err != nil {
+		return nil, err
+	}
+	if err := apikeyv1.RegisterApiKeyServiceHandlerClient(ctx, mux, apikeyv1.NewApiKeyServiceClient(conn)); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// incomingHeader forwards the X-Api-Key and X-Request-Id headers and the W3C
+// trace context headers on top of the headers the gateway forwards by
+// default, such as Authorization.
+func incomingHeader(key string) (string, bool) {
+	switch key = strings.ToLower(key); key {
+	case "x-api-key", "x-request-id", "traceparent", "tracestate":
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader returns the request ID as the X-Request-Id header the caller
+// may have sent, and other response metadata with the default Grpc-Metadata-
+// prefix.
+func outgoingHeader(key string) (string, bool) {
+	if key == "x-request-id" {
+		return "X-Request-Id", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,221 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mock_post "github.com/sdoshi579/cloudbees/internal/mockgen/service/post"
+	postrpc "github.com/sdoshi579/cloudbees/rpc/post"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGateway serves service over gRPC and returns a REST gateway in front of it.
+func newGateway(t *testing.T, service *mock_post.MockService, options ...grpc.ServerOption) http.Handler {
+	grpcServer := grpc.NewServer(options...)
+	postv1.RegisterPostServiceServer(grpcServer, postrpc.NewRPCImplementation(service, zap.NewNop()))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := Dial(lis.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	handler, err := NewHandler(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return handler
+}
+
+func serve(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGateway(t *testing.T) {
+	id := uuid.New()
+	publishedOn := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	post := &entity.PostDetail{ID: id, Title: "title", Content: "content", Author: "author",
+		PublishedOn: publishedOn, Tags: []string{"go"}, Revision: 1}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		setup      func(service *mock_post.MockService)
+		wantStatus int
+		// wantFields are compared against the top level of the JSON response.
+		wantFields map[string]any
+	}{
+		{
+			name:   "create",
+			method: http.MethodPost,
+			target: "/v1/posts",
+			body:   `{"title":"title","content":"content","author":"author","publishedOn":"2024-05-01T00:00:00Z","tags":["go"]}`,
+			setup: func(service *mock_post.MockService) {
+				service.EXPECT().CreatePost(gomock.Any(), entity.CreatePostRequest{Title: "title", Content: "content",
+					Author: "author", PublishedOn: publishedOn, Tags: []string{"go"}}).Return(post, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantFields: map[string]any{"success": true, "id": id.String(), "publishedOn": "2024-05-01T00:00:00Z"},
+		},
+		{
+			name:   "get",
+			method: http.MethodGet,
+			target: "/v1/posts/" + id.String(),
+			setup: func(service *mock_post.MockService) {
+				service.EXPECT().GetPost(gomock.Any(), id).Return(post, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantFields: map[string]any{"id": id.String(), "title": "title", "revision": "1"},
+		},
+		{
+			name:   "get missing post",
+			method: http.MethodGet,
+			target: "/v1/posts/" + id.String(),
+			setup: func(service *mock_post.MockService) {
+				service.EXPECT().GetPost(gomock.Any(), id).Return(nil, domainerror.NotFound("post", id.String()))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "patch",
+			method: http.MethodPatch,
+			target: "/v1/posts/" + id.String(),
+			body:   `{"title":"new title","updateMask":"title","expectedRevision":"1"}`,
+			setup: func(service *mock_post.MockService) {
+				service.EXPECT().UpdatePost(gomock.Any(), id, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id uuid.UUID, request entity.UpdatePostRequest) (*entity.PostDetail, error) {
+						if *request.Title != "new title" || request.UpdateMask[0] != "title" || request.ExpectedRevision != 1 {
+							t.Errorf("UpdatePost got %+v", request)
+						}
+						return post, nil
+					})
+			},
+			wantStatus: http.StatusOK,
+			wantFields: map[string]any{"success": true},
+		},
+		{
+			name:   "delete with query parameters",
+			method: http.MethodDelete,
+			target: "/v1/posts/" + id.String() + "?expectedRevision=2&allowMissing=true",
+			setup: func(service *mock_post.MockService) {
+				service.EXPECT().DeletePost(gomock.Any(), id, entity.DeletePostRequest{ExpectedRevision: 2, AllowMissing: true})
+			},
+			wantStatus: http.StatusOK,
+			wantFields: map[string]any{"success": true},
+		},
+		{
+			name:       "invalid id",
+			method:     http.MethodGet,
+			target:     "/v1/posts/not-a-uuid",
+			setup:      func(service *mock_post.MockService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown route",
+			method:     http.MethodGet,
+			target:     "/v1/comments",
+			setup:      func(service *mock_post.MockService) {},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := mock_post.NewMockService(ctrl)
+			tt.setup(service)
+
+			recorder := serve(newGateway(t, service), tt.method, tt.target, tt.body)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("%s %s = %d %s, want %d", tt.method, tt.target, recorder.Code, recorder.Body, tt.wantStatus)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("response is not JSON: %v: %s", err, recorder.Body)
+			}
+			for field, want := range tt.wantFields {
+				if body[field] != want {
+					t.Errorf("%s = %v, want %v", field, body[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGateway_forwardsCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	service := mock_post.NewMockService(ctrl)
+	service.EXPECT().ListPosts(gomock.Any(), gomock.Any()).Return(&entity.ListPostsResponse{}, nil)
+
+	var md metadata.MD
+	recordMetadata := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ = metadata.FromIncomingContext(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "req-1"))
+		return handler(ctx, req)
+	}
+	request := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	request.Header.Set("Authorization", "Bearer token")
+	request.Header.Set("X-Api-Key", "cbk_key")
+	request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	request.Header.Set("X-Request-Id", "req-1")
+	recorder := httptest.NewRecorder()
+	newGateway(t, service, grpc.UnaryInterceptor(recordMetadata)).ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /v1/posts = %d %s, want %d", recorder.Code, recorder.Body, http.StatusOK)
+	}
+	if got := md.Get("authorization"); len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("authorization metadata = %v, want the bearer token", got)
+	}
+	if got := md.Get("x-api-key"); len(got) != 1 || got[0] != "cbk_key" {
+		t.Errorf("x-api-key metadata = %v, want the api key", got)
+	}
+	if got := md.Get("traceparent"); len(got) != 1 || got[0] != request.Header.Get("Traceparent") {
+		t.Errorf("traceparent metadata = %v, want the trace context", got)
+	}
+	if got := md.Get("x-request-id"); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("x-request-id metadata = %v, want the request id", got)
+	}
+	if got := recorder.Header().Get("X-Request-Id"); got != "req-1" {
+		t.Errorf("X-Request-Id response header = %q, want the request id", got)
+	}
+}
+
+func TestDial_wildcardAddress(t *testing.T) {
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	conn, err := Dial(lis.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if want := "127.0.0.1:" + strconv.Itoa(lis.Addr().(*net.TCPAddr).Port); conn.Target() != want {
+		t.Errorf("Dial(%s) target = %s, want %s", lis.Addr(), conn.Target(), want)
+	}
+}
@@ -0,0 +1,53 @@
+// Package openapi serves the OpenAPI v2 document of the REST API, generated
+// from post.proto by protoc-gen-openapiv2, and a Swagger UI page to explore it.
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// Paths the document and the Swagger UI page are served on. The assets of the
+// page are served below UIPath.
+const (
+	SpecPath = "/openapi.json"
+	UIPath   = "/docs/"
+)
+
+var (
+	//go:embed api.swagger.json
+	spec []byte
+	//go:embed swagger-ui
+	swaggerUI embed.FS
+)
+
+// SpecHandler serves the OpenAPI document as JSON.
+func SpecHandler() http.Handler {
+	return allowGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}))
+}
+
+// UIHandler serves a Swagger UI page loading the document from SpecPath,
+// along with the Swagger UI assets, all of which are embedded so the page
+// loads nothing from third parties.
+func UIHandler() http.Handler {
+	assets, err := fs.Sub(swaggerUI, "swagger-ui")
+	if err != nil {
+		panic(err)
+	}
+	return allowGet(http.StripPrefix(UIPath, http.FileServer(http.FS(assets))))
+}
+
+func allowGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
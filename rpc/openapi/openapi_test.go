@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSpecHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	SpecHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, SpecPath, nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d", SpecPath, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+	var document struct {
+		Swagger string                    `json:"swagger"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &document); err != nil {
+		t.Fatalf("unmarshal error = %v", err)
+	}
+	if document.Swagger != "2.0" {
+		t.Errorf("swagger = %q, want 2.0", document.Swagger)
+	}
+	for path, method := range map[string]string{
+		"/v1/posts":      "post",
+		"/v1/posts/{id}": "patch",
+	} {
+		if _, ok := document.Paths[path][method]; !ok {
+			t.Errorf("document has no %s %s operation", method, path)
+		}
+	}
+}
+
+func TestUIHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	UIHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, UIPath, nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d", UIPath, recorder.Code)
+	}
+	page := recorder.Body.String()
+	if !strings.Contains(page, `url: "`+SpecPath+`"`) {
+		t.Errorf("Swagger UI page does not load %s", SpecPath)
+	}
+	if strings.Contains(page, "://") {
+		t.Errorf("Swagger UI page loads assets from another origin")
+	}
+}
+
+func TestUIHandler_assets(t *testing.T) {
+	tests := []struct {
+		path      string
+		mediaType string
+	}{
+		{path: UIPath + "swagger-ui.css", mediaType: "text/css"},
+		{path: UIPath + "swagger-ui-bundle.js", mediaType: "javascript"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			UIHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("GET %s = %d", tt.path, recorder.Code)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); !strings.Contains(contentType, tt.mediaType) {
+				t.Errorf("Content-Type = %q, want %s", contentType, tt.mediaType)
+			}
+			if recorder.Body.Len() == 0 {
+				t.Errorf("GET %s returned an empty body", tt.path)
+			}
+		})
+	}
+}
+
+func TestHandler_methodNotAllowed(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	SpecHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, SpecPath, nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST %s = %d, want %d", SpecPath, recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
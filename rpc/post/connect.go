@@ -0,0 +1,61 @@
+package post
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/gen/post/v1/postv1connect"
+	"github.com/sdoshi579/cloudbees/rpc/connectbridge"
+	"google.golang.org/grpc"
+)
+
+// ConnectHandler serves the post RPCs over the Connect, gRPC and gRPC-Web
+// protocols with the same implementation and interceptors as the grpc-go
+// server.
+type ConnectHandler struct {
+	server      postv1.PostServiceServer
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var _ postv1connect.PostServiceHandler = (*ConnectHandler)(nil)
+
+func NewConnectHandler(server postv1.PostServiceServer, interceptors ...grpc.UnaryServerInterceptor) *ConnectHandler {
+	return &ConnectHandler{
+		server:      server,
+		interceptor: connectbridge.ChainUnaryInterceptors(interceptors...),
+	}
+}
+
+func (h *ConnectHandler) Create(ctx context.Context, request *connect.Request[postv1.CreateRequest]) (*connect.Response[postv1.CreateResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Create)
+}
+func (h *ConnectHandler) Get(ctx context.Context, request *connect.Request[postv1.GetRequest]) (*connect.Response[postv1.GetResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Get)
+}
+func (h *ConnectHandler) Update(ctx context.Context, request *connect.Request[postv1.UpdateRequest]) (*connect.Response[postv1.UpdateResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Update)
+}
+func (h *ConnectHandler) Delete(ctx context.Context, request *connect.Request[postv1.DeleteRequest]) (*connect.Response[postv1.DeleteResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Delete)
+}
+func (h *ConnectHandler) List(ctx context.Context, request *connect.Request[postv1.ListRequest]) (*connect.Response[postv1.ListResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.List)
+}
+func (h *ConnectHandler) Restore(ctx context.Context, request *connect.Request[postv1.RestoreRequest]) (*connect.Response[postv1.RestoreResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Restore)
+}
+func (h *ConnectHandler) Purge(ctx context.Context, request *connect.Request[postv1.PurgeRequest]) (*connect.Response[postv1.PurgeResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.Purge)
+}
+func (h *ConnectHandler) ListRevisions(ctx context.Context, request *connect.Request[postv1.ListRevisionsRequest]) (*connect.Response[postv1.ListRevisionsResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.ListRevisions)
+}
+func (h *ConnectHandler) GetRevision(ctx context.Context, request *connect.Request[postv1.GetRevisionRequest]) (*connect.Response[postv1.GetRevisionResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.GetRevision)
+}
+func (h *ConnectHandler) DiffRevisions(ctx context.Context, request *connect.Request[postv1.DiffRevisionsRequest]) (*connect.Response[postv1.DiffRevisionsResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.DiffRevisions)
+}
+func (h *ConnectHandler) RestoreRevision(ctx context.Context, request *connect.Request[postv1.RestoreRevisionRequest]) (*connect.Response[postv1.RestoreRevisionResponse], error) {
+	return connectbridge.Unary(ctx, request, h.interceptor, h.server.RestoreRevision)
+}
@@ -0,0 +1,98 @@
+package post
+
+import (
+	"connectrpc.com/connect"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/gen/post/v1/postv1connect"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	mock_post "github.com/sdoshi579/cloudbees/internal/mockgen/service/post"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// recordingInterceptor records the method and the x-user metadata of every
+// call and answers with an x-served-by header.
+func recordingInterceptor(calls *[]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		*calls = append(*calls, info.FullMethod+" "+md.Get("x-user")[0])
+		grpc.SetHeader(ctx, metadata.Pairs("x-served-by", "interceptor"))
+		return handler(ctx, req)
+	}
+}
+
+func TestConnectHandler(t *testing.T) {
+	protocols := []struct {
+		name   string
+		option connect.ClientOption
+	}{
+		{name: "connect json", option: connect.WithProtoJSON()},
+		{name: "grpc", option: connect.WithGRPC()},
+		{name: "grpc-web", option: connect.WithGRPCWeb()},
+	}
+	for _, protocol := range protocols {
+		t.Run(protocol.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			service := mock_post.NewMockService(ctrl)
+			found, missing := uuid.New(), uuid.New()
+			service.EXPECT().GetPost(gomock.Any(), found).Return(&entity.PostDetail{ID: found, Title: "title"}, nil)
+			service.EXPECT().GetPost(gomock.Any(), missing).Return(nil, domainerror.NotFound("post", missing.String()))
+
+			var calls []string
+			handler := NewConnectHandler(NewRPCImplementation(service, zap.NewNop()), recordingInterceptor(&calls))
+			mux := http.NewServeMux()
+			mux.Handle(postv1connect.NewPostServiceHandler(handler))
+			server := httptest.NewUnstartedServer(mux)
+			server.EnableHTTP2 = true
+			server.StartTLS()
+			defer server.Close()
+			client := postv1connect.NewPostServiceClient(server.Client(), server.URL, protocol.option)
+
+			request := connect.NewRequest(&postv1.GetRequest{Id: found.String()})
+			request.Header().Set("x-user", "alice")
+			response, err := client.Get(context.Background(), request)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if response.Msg.Title != "title" {
+				t.Errorf("Get() title = %q, want title", response.Msg.Title)
+			}
+			if servedBy := response.Header().Get("x-served-by"); servedBy != "interceptor" {
+				t.Errorf("x-served-by header = %q, want interceptor", servedBy)
+			}
+
+			request = connect.NewRequest(&postv1.GetRequest{Id: missing.String()})
+			request.Header().Set("x-user", "bob")
+			_, err = client.Get(context.Background(), request)
+			if connect.CodeOf(err) != connect.CodeNotFound {
+				t.Fatalf("Get() error = %v, want code %v", err, connect.CodeNotFound)
+			}
+			var connectErr *connect.Error
+			if !errors.As(err, &connectErr) || len(connectErr.Details()) == 0 {
+				t.Fatalf("Get() error = %v, want error details", err)
+			}
+			detail, err := connectErr.Details()[0].Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := detail.(*errdetails.ResourceInfo); !ok {
+				t.Errorf("error detail = %T, want *errdetails.ResourceInfo", detail)
+			}
+
+			want := []string{postv1.PostService_Get_FullMethodName + " alice", postv1.PostService_Get_FullMethodName + " bob"}
+			if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] {
+				t.Errorf("interceptor calls = %v, want %v", calls, want)
+			}
+		})
+	}
+}
@@ -2,13 +2,15 @@ package post
 
 import (
 	"context"
-	"errors"
 	"github.com/google/uuid"
 	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
 	"github.com/sdoshi579/cloudbees/internal/entity"
 	"github.com/sdoshi579/cloudbees/internal/service/post"
+	"github.com/sdoshi579/cloudbees/rpc/rpcerror"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
 )
 
 type RPCImplementation struct {
@@ -29,18 +31,14 @@ func (r *RPCImplementation) Create(ctx context.Context, request *postv1.CreateRe
 		Title:       request.Title,
 		Content:     request.Content,
 		Author:      request.Author,
-		PublishedOn: request.PublishedOn.AsTime(),
+		PublishedOn: asTime(request.PublishedOn),
 		Tags:        request.Tags,
 	}
 
 	resp, err := r.service.CreatePost(ctx, entityRequest)
 
 	if err != nil {
-		r.logger.Error("error in creating post", zap.Error(err), zap.Any("request", request))
-		return &postv1.CreateResponse{
-			Success: false,
-			Message: err.Error(),
-		}, err
+		return nil, rpcerror.ToStatus(err)
 	}
 
 	return &postv1.CreateResponse{
@@ -51,22 +49,19 @@ func (r *RPCImplementation) Create(ctx context.Context, request *postv1.CreateRe
 		Author:      resp.Author,
 		PublishedOn: timestamppb.New(resp.PublishedOn),
 		Tags:        resp.Tags,
+		Revision:    resp.Revision,
+		Owner:       resp.Owner,
 	}, nil
 }
 func (r *RPCImplementation) Get(ctx context.Context, request *postv1.GetRequest) (*postv1.GetResponse, error) {
-	postID, err := uuid.Parse(request.Id)
+	postID, err := parsePostID(request.Id)
 	if err != nil {
-		r.logger.Error("error in parsing post id", zap.Error(err), zap.Any("request", request))
-		return nil, errors.New("invalid post id")
+		return nil, rpcerror.ToStatus(err)
 	}
 	resp, err := r.service.GetPost(ctx, postID)
 
 	if err != nil {
-		r.logger.Error("error in fetching post", zap.Error(err), zap.Any("request", request))
-		return &postv1.GetResponse{
-			Success: false,
-			Message: err.Error(),
-		}, err
+		return nil, rpcerror.ToStatus(err)
 	}
 
 	return &postv1.GetResponse{
@@ -77,30 +72,33 @@ func (r *RPCImplementation) Get(ctx context.Context, request *postv1.GetRequest)
 		Author:      resp.Author,
 		PublishedOn: timestamppb.New(resp.PublishedOn),
 		Tags:        resp.Tags,
+		Revision:    resp.Revision,
+		Owner:       resp.Owner,
 	}, nil
 }
 func (r *RPCImplementation) Update(ctx context.Context, request *postv1.UpdateRequest) (*postv1.UpdateResponse, error) {
-	postID, err := uuid.Parse(request.Id)
+	postID, err := parsePostID(request.Id)
 	if err != nil {
-		r.logger.Error("error in parsing post id", zap.Error(err), zap.Any("request", request))
-		return nil, errors.New("invalid post id")
+		return nil, rpcerror.ToStatus(err)
 	}
 
 	entityRequest := entity.UpdatePostRequest{
-		Title:   request.Title,
-		Content: request.Content,
-		Author:  request.Author,
-		Tags:    request.Tags,
+		Title:            request.Title,
+		Content:          request.Content,
+		Author:           request.Author,
+		Tags:             request.Tags,
+		UpdateMask:       updateMaskPaths(request),
+		ExpectedRevision: request.ExpectedRevision,
+	}
+	if request.PublishedOn != nil {
+		publishedOn := request.PublishedOn.AsTime()
+		entityRequest.PublishedOn = &publishedOn
 	}
 
 	resp, err := r.service.UpdatePost(ctx, postID, entityRequest)
 
 	if err != nil {
-		r.logger.Error("error in updating post", zap.Error(err), zap.Any("request", request))
-		return &postv1.UpdateResponse{
-			Success: false,
-			Message: err.Error(),
-		}, err
+		return nil, rpcerror.ToStatus(err)
 	}
 
 	return &postv1.UpdateResponse{
@@ -111,25 +109,169 @@ func (r *RPCImplementation) Update(ctx context.Context, request *postv1.UpdateRe
 		Author:      resp.Author,
 		PublishedOn: timestamppb.New(resp.PublishedOn),
 		Tags:        resp.Tags,
+		Revision:    resp.Revision,
+		Owner:       resp.Owner,
 	}, nil
 }
 func (r *RPCImplementation) Delete(ctx context.Context, request *postv1.DeleteRequest) (*postv1.DeleteResponse, error) {
-	postID, err := uuid.Parse(request.Id)
+	postID, err := parsePostID(request.Id)
 	if err != nil {
-		r.logger.Error("error in parsing post id", zap.Error(err), zap.Any("request", request))
-		return nil, errors.New("invalid post id")
+		return nil, rpcerror.ToStatus(err)
 	}
-	resp, err := r.service.DeletePost(ctx, postID)
+	err = r.service.DeletePost(ctx, postID, entity.DeletePostRequest{
+		ExpectedRevision: request.ExpectedRevision,
+		AllowMissing:     request.AllowMissing,
+	})
 
-	if err != nil || !resp {
-		r.logger.Error("error in fetching post", zap.Error(err), zap.Any("request", request))
-		return &postv1.DeleteResponse{
-			Success: false,
-			Message: err.Error(),
-		}, err
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
 	}
 
 	return &postv1.DeleteResponse{
 		Success: true,
 	}, nil
 }
+func (r *RPCImplementation) Restore(ctx context.Context, request *postv1.RestoreRequest) (*postv1.RestoreResponse, error) {
+	postID, err := parsePostID(request.Id)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	resp, err := r.service.RestorePost(ctx, postID)
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	return &postv1.RestoreResponse{
+		Success: true,
+		Post:    toPostMessage(resp),
+	}, nil
+}
+func (r *RPCImplementation) Purge(ctx context.Context, request *postv1.PurgeRequest) (*postv1.PurgeResponse, error) {
+	postID, err := parsePostID(request.Id)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	if err := r.service.PurgePost(ctx, postID); err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	return &postv1.PurgeResponse{
+		Success: true,
+	}, nil
+}
+func (r *RPCImplementation) List(ctx context.Context, request *postv1.ListRequest) (*postv1.ListResponse, error) {
+	entityRequest := entity.ListPostsRequest{
+		PageSize:  int(request.PageSize),
+		PageToken: request.PageToken,
+		Sort: entity.PostSort{
+			Field:      toEntitySortField(request.SortBy),
+			Descending: request.Descending,
+		},
+	}
+	if filter := request.Filter; filter != nil {
+		entityRequest.Filter = entity.PostFilter{
+			Author: filter.Author,
+			Tags:   filter.Tags,
+		}
+		if filter.TagMatch == postv1.TagMatch_TAG_MATCH_ALL {
+			entityRequest.Filter.TagMatch = entity.TagMatchAll
+		}
+		if filter.PublishedAfter != nil {
+			publishedAfter := filter.PublishedAfter.AsTime()
+			entityRequest.Filter.PublishedAfter = &publishedAfter
+		}
+		if filter.PublishedBefore != nil {
+			publishedBefore := filter.PublishedBefore.AsTime()
+			entityRequest.Filter.PublishedBefore = &publishedBefore
+		}
+	}
+
+	resp, err := r.service.ListPosts(ctx, entityRequest)
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	posts := make([]*postv1.Post, 0, len(resp.Posts))
+	for _, p := range resp.Posts {
+		posts = append(posts, toPostMessage(p))
+	}
+
+	return &postv1.ListResponse{
+		Success:       true,
+		Posts:         posts,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func toPostMessage(p *entity.PostDetail) *postv1.Post {
+	return &postv1.Post{
+		Id:          p.ID.String(),
+		Title:       p.Title,
+		Content:     p.Content,
+		Author:      p.Author,
+		PublishedOn: timestamppb.New(p.PublishedOn),
+		Tags:        p.Tags,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		Revision:    p.Revision,
+		Owner:       p.Owner,
+	}
+}
+
+func toEntitySortField(field postv1.SortField) entity.PostSortField {
+	switch field {
+	case postv1.SortField_SORT_FIELD_PUBLISHED_ON:
+		return entity.PostSortByPublishedOn
+	case postv1.SortField_SORT_FIELD_TITLE:
+		return entity.PostSortByTitle
+	default:
+		return entity.PostSortByCreatedAt
+	}
+}
+
+func parsePostID(id string) (uuid.UUID, error) {
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, domainerror.InvalidArgument("invalid post id", domainerror.FieldViolation{
+			Field:       "id",
+			Description: "must be a valid UUID",
+		}).Wrap(err)
+	}
+	return postID, nil
+}
+
+// asTime converts a timestamp, keeping an unset timestamp as the zero time
+// instead of the Unix epoch that AsTime would return.
+func asTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// updateMaskPaths returns the paths of the request's update mask. Requests
+// without a mask update the fields that are set, and tags only when non-empty.
+func updateMaskPaths(request *postv1.UpdateRequest) []string {
+	if paths := request.GetUpdateMask().GetPaths(); len(paths) != 0 {
+		return paths
+	}
+	var paths []string
+	if request.Title != nil {
+		paths = append(paths, entity.PostFieldTitle)
+	}
+	if request.Content != nil {
+		paths = append(paths, entity.PostFieldContent)
+	}
+	if request.Author != nil {
+		paths = append(paths, entity.PostFieldAuthor)
+	}
+	if request.PublishedOn != nil {
+		paths = append(paths, entity.PostFieldPublishedOn)
+	}
+	if len(request.Tags) != 0 {
+		paths = append(paths, entity.PostFieldTags)
+	}
+	return paths
+}
@@ -0,0 +1,125 @@
+package post
+
+import (
+	"context"
+	postv1 "github.com/sdoshi579/cloudbees/gen/post/v1"
+	"github.com/sdoshi579/cloudbees/internal/entity"
+	"github.com/sdoshi579/cloudbees/rpc/rpcerror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (r *RPCImplementation) ListRevisions(ctx context.Context,
+	request *postv1.ListRevisionsRequest) (*postv1.ListRevisionsResponse, error) {
+	postID, err := parsePostID(request.PostId)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	resp, err := r.service.ListRevisions(ctx, postID, entity.ListRevisionsRequest{
+		PageSize:  int(request.PageSize),
+		PageToken: request.PageToken,
+	})
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	revisions := make([]*postv1.PostRevision, 0, len(resp.Revisions))
+	for _, revision := range resp.Revisions {
+		revisions = append(revisions, toRevisionMessage(revision))
+	}
+	return &postv1.ListRevisionsResponse{
+		Success:       true,
+		Revisions:     revisions,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func (r *RPCImplementation) GetRevision(ctx context.Context,
+	request *postv1.GetRevisionRequest) (*postv1.GetRevisionResponse, error) {
+	postID, err := parsePostID(request.PostId)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	resp, err := r.service.GetRevision(ctx, postID, request.Revision)
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	return &postv1.GetRevisionResponse{
+		Success:  true,
+		Revision: toRevisionMessage(resp),
+	}, nil
+}
+
+func (r *RPCImplementation) DiffRevisions(ctx context.Context,
+	request *postv1.DiffRevisionsRequest) (*postv1.DiffRevisionsResponse, error) {
+	postID, err := parsePostID(request.PostId)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	resp, err := r.service.DiffRevisions(ctx, postID, request.FromRevision, request.ToRevision)
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	contentDiff := make([]*postv1.DiffLine, 0, len(resp.ContentDiff))
+	for _, line := range resp.ContentDiff {
+		contentDiff = append(contentDiff, &postv1.DiffLine{
+			Operation: toDiffOperation(line.Operation),
+			Text:      line.Text,
+		})
+	}
+	return &postv1.DiffRevisionsResponse{
+		Success:       true,
+		ChangedFields: resp.ChangedFields,
+		ContentDiff:   contentDiff,
+	}, nil
+}
+
+func (r *RPCImplementation) RestoreRevision(ctx context.Context,
+	request *postv1.RestoreRevisionRequest) (*postv1.RestoreRevisionResponse, error) {
+	postID, err := parsePostID(request.PostId)
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+	resp, err := r.service.RestoreRevision(ctx, postID, entity.RestoreRevisionRequest{
+		Revision:         request.Revision,
+		ExpectedRevision: request.ExpectedRevision,
+	})
+
+	if err != nil {
+		return nil, rpcerror.ToStatus(err)
+	}
+
+	return &postv1.RestoreRevisionResponse{
+		Success: true,
+		Post:    toPostMessage(resp),
+	}, nil
+}
+
+func toRevisionMessage(revision *entity.PostRevision) *postv1.PostRevision {
+	return &postv1.PostRevision{
+		PostId:      revision.PostID.String(),
+		Revision:    revision.Revision,
+		Title:       revision.Title,
+		Content:     revision.Content,
+		Author:      revision.Author,
+		PublishedOn: timestamppb.New(revision.PublishedOn),
+		Tags:        revision.Tags,
+		CreatedAt:   timestamppb.New(revision.CreatedAt),
+		ChangedBy:   revision.ChangedBy,
+	}
+}
+
+func toDiffOperation(operation entity.DiffOperation) postv1.DiffOperation {
+	switch operation {
+	case entity.DiffInsert:
+		return postv1.DiffOperation_DIFF_OPERATION_INSERT
+	case entity.DiffDelete:
+		return postv1.DiffOperation_DIFF_OPERATION_DELETE
+	default:
+		return postv1.DiffOperation_DIFF_OPERATION_EQUAL
+	}
+}
@@ -0,0 +1,77 @@
+// Package rpcerror translates domain errors into gRPC status errors.
+package rpcerror
+
+import (
+	"context"
+	"errors"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ToStatus converts err into a status error carrying the matching code and
+// error details. Errors that are not domain errors become codes.Internal
+// without leaking their message to the client.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var domainErr *domainerror.Error
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	st := status.New(code(domainErr.Kind), domainErr.Message)
+	var details []protoadapt.MessageV1
+	if len(domainErr.Violations) != 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range domainErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if domainErr.Resource != "" {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: domainErr.Resource,
+			ResourceName: domainErr.ResourceID,
+			Description:  domainErr.Message,
+		})
+	}
+	if len(details) != 0 {
+		if withDetails, err := st.WithDetails(details...); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
+
+func code(kind domainerror.Kind) codes.Code {
+	switch kind {
+	case domainerror.KindNotFound:
+		return codes.NotFound
+	case domainerror.KindInvalidArgument:
+		return codes.InvalidArgument
+	case domainerror.KindAlreadyExists:
+		return codes.AlreadyExists
+	case domainerror.KindFailedPrecondition:
+		return codes.FailedPrecondition
+	case domainerror.KindConflict:
+		return codes.Aborted
+	case domainerror.KindPermissionDenied:
+		return codes.PermissionDenied
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,118 @@
+package rpcerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sdoshi579/cloudbees/internal/domainerror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{
+			name:    "not found",
+			err:     domainerror.NotFound("post", "42"),
+			code:    codes.NotFound,
+			message: "post 42 not found",
+		},
+		{
+			name:    "wrapped invalid argument",
+			err:     fmt.Errorf("creating post: %w", domainerror.InvalidArgument("title is required")),
+			code:    codes.InvalidArgument,
+			message: "title is required",
+		},
+		{
+			name:    "already exists",
+			err:     domainerror.AlreadyExists("post", "42"),
+			code:    codes.AlreadyExists,
+			message: "post 42 already exists",
+		},
+		{
+			name:    "failed precondition",
+			err:     domainerror.FailedPrecondition("post is deleted"),
+			code:    codes.FailedPrecondition,
+			message: "post is deleted",
+		},
+		{
+			name:    "conflict",
+			err:     domainerror.Conflict("post", "42", "post was modified concurrently"),
+			code:    codes.Aborted,
+			message: "post was modified concurrently",
+		},
+		{
+			name:    "permission denied",
+			err:     domainerror.PermissionDenied("only the owner or an editor can update the post"),
+			code:    codes.PermissionDenied,
+			message: "only the owner or an editor can update the post",
+		},
+		{
+			name:    "unknown error is not leaked",
+			err:     errors.New("sql: database is locked"),
+			code:    codes.Internal,
+			message: "internal error",
+		},
+		{
+			name:    "context deadline",
+			err:     context.DeadlineExceeded,
+			code:    codes.DeadlineExceeded,
+			message: context.DeadlineExceeded.Error(),
+		},
+		{
+			name:    "status error is kept",
+			err:     status.Error(codes.Unauthenticated, "missing token"),
+			code:    codes.Unauthenticated,
+			message: "missing token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(ToStatus(tt.err))
+			if st.Code() != tt.code {
+				t.Errorf("ToStatus() code = %v, want %v", st.Code(), tt.code)
+			}
+			if st.Message() != tt.message {
+				t.Errorf("ToStatus() message = %q, want %q", st.Message(), tt.message)
+			}
+		})
+	}
+}
+
+func TestToStatus_details(t *testing.T) {
+	err := ToStatus(domainerror.InvalidArgument("invalid post id", domainerror.FieldViolation{
+		Field:       "id",
+		Description: "must be a valid UUID",
+	}))
+	details := status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("ToStatus() details = %v, want a single BadRequest", details)
+	}
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "id" {
+		t.Errorf("ToStatus() details = %v, want a violation on id", details[0])
+	}
+
+	err = ToStatus(domainerror.NotFound("post", "42"))
+	details = status.Convert(err).Details()
+	if len(details) != 1 {
+		t.Fatalf("ToStatus() details = %v, want a single ResourceInfo", details)
+	}
+	resourceInfo, ok := details[0].(*errdetails.ResourceInfo)
+	if !ok || resourceInfo.ResourceType != "post" || resourceInfo.ResourceName != "42" {
+		t.Errorf("ToStatus() details = %v, want post 42", details[0])
+	}
+}
+
+func TestToStatus_nil(t *testing.T) {
+	if err := ToStatus(nil); err != nil {
+		t.Errorf("ToStatus(nil) = %v, want nil", err)
+	}
+}